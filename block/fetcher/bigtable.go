@@ -5,34 +5,40 @@ import (
 	"compress/bzip2"
 	"compress/gzip"
 	"context"
-	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"math/big"
-	"os/exec"
 	"strings"
 	"time"
 
 	"cloud.google.com/go/bigtable"
 	"github.com/klauspost/compress/zstd"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/firehose-solana/patches"
 	pbsolv1 "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
 	"github.com/streamingfast/logging"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type BigtableBlockReader struct {
 	bt             *bigtable.Client
 	maxConnAttempt uint64
+	patches        *patches.Registry
 
 	logger *zap.Logger
 	tracer logging.Tracer
 }
 
-func NewBigtableReader(bt *bigtable.Client, maxConnectionAttempt uint64, logger *zap.Logger, tracer logging.Tracer) *BigtableBlockReader {
+func NewBigtableReader(bt *bigtable.Client, maxConnectionAttempt uint64, chainPatches *patches.Registry, logger *zap.Logger, tracer logging.Tracer) *BigtableBlockReader {
 	return &BigtableBlockReader{
 		bt:             bt,
+		patches:        chainPatches,
 		logger:         logger,
 		tracer:         tracer,
 		maxConnAttempt: maxConnectionAttempt,
@@ -50,6 +56,9 @@ func (r *BigtableBlockReader) Read(
 	var seenStartBlock bool
 	var lastSeenBlock *pbsolv1.Block
 	var fatalError error
+	// skippedByPatches holds the slot of the blocks dropped because chain patches skip them, by
+	// blockhash
+	skippedByPatches := map[string]uint64{}
 
 	r.logger.Info("launching firehose-solana reprocessing",
 		zap.Uint64("start_block_num", startBlockNum),
@@ -77,6 +86,12 @@ func (r *BigtableBlockReader) Read(
 				return false
 			}
 
+			if r.patches.IsSkipped(blk.Slot) {
+				r.logger.Info("skipping block skipped by chain patches", zap.Uint64("block_num", blk.Slot))
+				skippedByPatches[blk.Blockhash] = blk.Slot
+				return true
+			}
+
 			if !seenStartBlock {
 				if blk.Slot < startBlockNum {
 					r.logger.Debug("skipping blow below start block",
@@ -94,6 +109,12 @@ func (r *BigtableBlockReader) Read(
 				return true
 			}
 
+			if skippedSlot, found := skippedByPatches[blk.PreviousBlockhash]; found {
+				// Reading again would drop the parent again, the block needs a previous blockhash patch
+				fatalError = fmt.Errorf("block %d (%s) links to block %d (%s) skipped by chain patches, its previous blockhash must be patched", blk.Slot, blk.Blockhash, skippedSlot, blk.PreviousBlockhash)
+				return false
+			}
+
 			if lastSeenBlock != nil && (lastSeenBlock.Blockhash != blk.PreviousBlockhash) {
 				// Weird cases where we do not receive the next linkeable block.
 				// we should try to reconnect
@@ -173,6 +194,171 @@ func (r *BigtableBlockReader) progressLog(blk *pbsolv1.Block, zlogger *zap.Logge
 
 }
 
+// BlockRange is a [Start, Stop) range of slots.
+type BlockRange struct {
+	Start uint64
+	Stop  uint64
+}
+
+func (r BlockRange) String() string {
+	return fmt.Sprintf("[%d, %d)", r.Start, r.Stop)
+}
+
+// SplitInShards splits [start, stop) into at most shardCount contiguous ranges, each one
+// starting and stopping on a 100 blocks bundle boundary so that shards can be merged
+// independently. Start is rounded down and stop rounded up to the closest boundary.
+func SplitInShards(start, stop uint64, shardCount int) []BlockRange {
+	start = start - start%100
+	if stop%100 != 0 {
+		stop = stop - stop%100 + 100
+	}
+	if stop <= start || shardCount <= 0 {
+		return nil
+	}
+
+	bundleCount := (stop - start) / 100
+	bundlesPerShard := bundleCount / uint64(shardCount)
+	if bundleCount%uint64(shardCount) != 0 {
+		bundlesPerShard++
+	}
+
+	var out []BlockRange
+	for shardStart := start; shardStart < stop; shardStart += bundlesPerShard * 100 {
+		out = append(out, BlockRange{Start: shardStart, Stop: min(shardStart+bundlesPerShard*100, stop)})
+	}
+	return out
+}
+
+// ReadShards reads every shard concurrently, running at most `workers` of them at the same
+// time. Each shard gets its own processBlock function from newShardProcessor, which must
+// return io.EOF once it has seen a block at or after the shard's stop, like
+// firecore.MergedBlocksWriter does. A shard ending without such a block is an error since
+// its last bundle would be incomplete.
+func (r *BigtableBlockReader) ReadShards(
+	ctx context.Context,
+	shards []BlockRange,
+	workers int,
+	newShardProcessor func(shard BlockRange) func(block *pbsolv1.Block) error,
+) error {
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+
+	for _, shard := range shards {
+		shard := shard
+		group.Go(func() error {
+			r.logger.Info("reading shard", zap.Stringer("shard", shard))
+			err := r.Read(ctx, shard.Start, shard.Stop, newShardProcessor(shard))
+			if errors.Is(err, io.EOF) {
+				r.logger.Info("shard completed", zap.Stringer("shard", shard))
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("reading shard %s: %w", shard, err)
+			}
+			return fmt.Errorf("reading shard %s: rows ended before reaching shard stop", shard)
+		})
+	}
+
+	return group.Wait()
+}
+
+// BigtableFetcher exposes a BigtableBlockReader as a blockpoller.BlockFetcher so that
+// Bigtable sourced blocks go through the same poller and FIRE output path as the RPC fetcher.
+type BigtableFetcher struct {
+	reader                   *BigtableBlockReader
+	stopBlockNum             uint64
+	latestBlockRetryInterval time.Duration
+	logger                   *zap.Logger
+}
+
+func NewBigtableFetcher(reader *BigtableBlockReader, stopBlockNum uint64, latestBlockRetryInterval time.Duration, logger *zap.Logger) *BigtableFetcher {
+	return &BigtableFetcher{
+		reader:                   reader,
+		stopBlockNum:             stopBlockNum,
+		latestBlockRetryInterval: latestBlockRetryInterval,
+		logger:                   logger,
+	}
+}
+
+func (f *BigtableFetcher) IsBlockAvailable(requestedSlot uint64) bool {
+	return f.stopBlockNum == 0 || requestedSlot <= f.stopBlockNum
+}
+
+func (f *BigtableFetcher) Fetch(ctx context.Context, requestedSlot uint64) (out *pbbstream.Block, skip bool, err error) {
+	if f.reader.patches.IsSkipped(requestedSlot) {
+		f.logger.Info("fetcher block skipped by chain patches", zap.Uint64("block_num", requestedSlot))
+		return nil, true, nil
+	}
+
+	table := f.reader.bt.Open("blocks")
+
+	for {
+		// Skipped slots have no row in Bigtable, so we read the first row at or after the
+		// requested slot: if it is a later slot, the requested one was skipped.
+		var found bigtable.Row
+		btRange := bigtable.NewRange(fmt.Sprintf("%016x", requestedSlot), "")
+		err := table.ReadRows(ctx, btRange, func(row bigtable.Row) bool {
+			found = row
+			return false
+		}, bigtable.LimitRows(1))
+		if err != nil {
+			return nil, false, fmt.Errorf("reading row for slot %d: %w", requestedSlot, err)
+		}
+
+		if found == nil {
+			f.logger.Info("block not yet available in bigtable, waiting", zap.Uint64("block_num", requestedSlot))
+			select {
+			case <-ctx.Done():
+				return nil, false, ctx.Err()
+			case <-time.After(f.latestBlockRetryInterval):
+			}
+			continue
+		}
+
+		blk, zlogger, err := f.reader.ProcessRow(found)
+		if err != nil {
+			return nil, false, fmt.Errorf("processing row for slot %d: %w", requestedSlot, err)
+		}
+
+		if blk.Slot != requestedSlot {
+			f.logger.Info("fetcher block was skipped", zap.Uint64("block_num", requestedSlot), zap.Uint64("next_block_num", blk.Slot))
+			return nil, true, nil
+		}
+
+		f.reader.progressLog(blk, zlogger)
+
+		block, err := BstreamBlockFromBlock(blk)
+		if err != nil {
+			return nil, false, fmt.Errorf("converting block %d: %w", requestedSlot, err)
+		}
+		return block, false, nil
+	}
+}
+
+// BstreamBlockFromBlock wraps an historical block. Everything stored in Bigtable is
+// finalized, so the parent slot is used as LIB like GetFirehoseBlockLIBNum does.
+func BstreamBlockFromBlock(blk *pbsolv1.Block) (*pbbstream.Block, error) {
+	payload, err := anypb.New(blk)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal block: %w", err)
+	}
+
+	var timeStamp *timestamppb.Timestamp
+	if blk.BlockTime != nil {
+		timeStamp = timestamppb.New(blk.GetFirehoseBlockTime())
+	}
+
+	return &pbbstream.Block{
+		Number:    blk.Slot,
+		Id:        blk.Blockhash,
+		ParentId:  blk.PreviousBlockhash,
+		Timestamp: timeStamp,
+		LibNum:    blk.GetFirehoseBlockLIBNum(),
+		ParentNum: blk.ParentSlot,
+		Payload:   payload,
+	}, nil
+}
+
 type RowType string
 
 const (
@@ -200,22 +386,11 @@ func (r *BigtableBlockReader) ProcessRow(row bigtable.Row) (*pbsolv1.Block, *zap
 		zap.String("row_key", row.Key()),
 	)
 
-	var cnt []byte
-	var err error
-
-	switch rowType {
-	case RowTypeBin:
-		cnt, err = externalBinToProto(rowCnt, "solana-bigtable-decoder", "--hex")
-		if err != nil {
-			return nil, zlogger, fmt.Errorf("unable get decode bin with external command 'solana-bigtable-decoder'  %s: %w", blockNum.String(), err)
-		}
-	default:
-		cnt, err = r.decompress(rowCnt)
-		if err != nil {
-			return nil, zlogger, fmt.Errorf("unable to decompress block %s (uncompresse length %d): %w", blockNum.String(), len(rowCnt), err)
-		}
-
+	cnt, err := r.decompress(rowCnt)
+	if err != nil {
+		return nil, zlogger, fmt.Errorf("unable to decompress block %s (uncompresse length %d): %w", blockNum.String(), len(rowCnt), err)
 	}
+
 	zlogger.Debug("found bigtable row",
 		zap.Stringer("blk_num", blockNum),
 		zap.String("key", row.Key()),
@@ -224,75 +399,34 @@ func (r *BigtableBlockReader) ProcessRow(row bigtable.Row) (*pbsolv1.Block, *zap
 		zap.String("row_type", string(rowType)),
 	)
 
-	blk := &pbsolv1.Block{}
-	if err := proto.Unmarshal(cnt, blk); err != nil {
-		return nil, zlogger, fmt.Errorf("unable to unmarshall confirmed block: %w", err)
+	var blk *pbsolv1.Block
+	switch rowType {
+	case RowTypeBin:
+		blk, err = DecodeStoredConfirmedBlock(cnt)
+		if err != nil {
+			return nil, zlogger, fmt.Errorf("unable to decode bincode block %s: %w", blockNum.String(), err)
+		}
+	default:
+		blk = &pbsolv1.Block{}
+		if err := proto.Unmarshal(cnt, blk); err != nil {
+			return nil, zlogger, fmt.Errorf("unable to unmarshall confirmed block: %w", err)
+		}
 	}
 	blk.Slot = blockNum.Uint64()
 
-	// horrible tweaks
-	switch blk.Blockhash {
-	case "Goi3t9JjgDkyULZbM2TzE5QqHP1fPeMcHNaXNFBCBv1v":
-		zlogger.Warn("applying horrible tweak to block Goi3t9JjgDkyULZbM2TzE5QqHP1fPeMcHNaXNFBCBv1v")
-		if blk.PreviousBlockhash == "11111111111111111111111111111111" {
-			blk.PreviousBlockhash = "HQEr9qcbUVBt2okfu755FdJvJrPYTSpzzmmyeWTj5oau"
-		}
-	case "6UFQveZ94DUKGbcLFoyayn1QwthVfD3ZqvrM2916pHCR":
-		zlogger.Warn("applying horrible tweak to block 63,072,071")
-		if blk.PreviousBlockhash == "11111111111111111111111111111111" {
-			blk.PreviousBlockhash = "7cLQx2cZvyKbGoMuutXEZ3peg3D21D5qbX19T5V1XEiK"
-		}
-	case "Fqbm7QvCTYnToXWcCw6nbkWhMmXx2Nv91LsXBrKraB43":
-		zlogger.Warn("applying horrible tweak to block 53,135,959")
-		if blk.PreviousBlockhash == "11111111111111111111111111111111" {
-			blk.PreviousBlockhash = "RfXUrekgajPSb1R4CGFJWNaHTnB6p53Tzert4gouj2u"
-		}
-	case "ABp9G2NaPzM6kQbeyZYCYgdzL8JN9AxSSbCQG2X1K9UF":
-		zlogger.Warn("applying horrible tweak to block 46,223,993")
-		if blk.PreviousBlockhash == "11111111111111111111111111111111" {
-			blk.PreviousBlockhash = "9F2C7TGqUpFu6krd8vQbUv64BskrneBSgY7U2QfrGx96"
-		}
-	case "ByUxmGuaT7iQS9qGS8on5xHRjiHXcGxvwPPaTGZXQyz7":
-		zlogger.Warn("applying horrible tweak to block 61,328,766")
-		if blk.PreviousBlockhash == "11111111111111111111111111111111" {
-			blk.PreviousBlockhash = "J6rRToKMK5DQDzVLqo7ibL3snwBYtqkYnRnQ7vXoUSEc"
-		}
+	previousBlockhash := blk.PreviousBlockhash
+	if r.patches.Apply(blk) {
+		zlogger.Warn("patching previous block hash", zap.String("block_hash", blk.Blockhash), zap.String("original_previous_block_hash", previousBlockhash), zap.String("previous_block_hash", blk.PreviousBlockhash))
 	}
 
 	return blk, zlogger, nil
 }
 
-func externalBinToProto(in []byte, command string, args ...string) ([]byte, error) {
-	cmd := exec.Command(command, args...)
-	stdin, err := cmd.StdinPipe()
-	if err != nil {
-		return nil, fmt.Errorf("failed to run command: %w", err)
-	}
-
-	inString := hex.EncodeToString(in)
-
-	go func() {
-		defer stdin.Close()
-		io.WriteString(stdin, inString)
-	}()
-
-	outCntHex, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed get command output: %w", err)
-	}
-	outHex := string(outCntHex)
-	if strings.HasPrefix(outHex, "0x") {
-		outHex = outHex[2:]
-	}
-	outHex = strings.TrimRight(outHex, "\n")
-	cnt, err := hex.DecodeString(outHex)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode output string %q: %w", outHex, err)
+func (r *BigtableBlockReader) decompress(in []byte) (out []byte, err error) {
+	if len(in) < 4 {
+		return nil, fmt.Errorf("row too short to hold a compression header: %d bytes", len(in))
 	}
-	return cnt, nil
-}
 
-func (r *BigtableBlockReader) decompress(in []byte) (out []byte, err error) {
 	switch in[0] {
 	case 0:
 		r.logger.Debug("no compression found")
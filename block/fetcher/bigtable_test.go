@@ -0,0 +1,181 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigtable"
+	"cloud.google.com/go/bigtable/bttest"
+	"github.com/streamingfast/firehose-solana/patches"
+	pbsolv1 "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_SplitInShards(t *testing.T) {
+	cases := []struct {
+		name       string
+		start      uint64
+		stop       uint64
+		shardCount int
+		expected   []BlockRange
+	}{
+		{
+			name:       "even split",
+			start:      0,
+			stop:       400,
+			shardCount: 2,
+			expected:   []BlockRange{{0, 200}, {200, 400}},
+		},
+		{
+			name:       "uneven split",
+			start:      1000,
+			stop:       1500,
+			shardCount: 2,
+			expected:   []BlockRange{{1000, 1300}, {1300, 1500}},
+		},
+		{
+			name:       "unaligned bounds are rounded to bundle boundaries",
+			start:      1042,
+			stop:       1201,
+			shardCount: 4,
+			expected:   []BlockRange{{1000, 1100}, {1100, 1200}, {1200, 1300}},
+		},
+		{
+			name:       "more shards than bundles",
+			start:      0,
+			stop:       100,
+			shardCount: 10,
+			expected:   []BlockRange{{0, 100}},
+		},
+		{
+			name:       "empty range",
+			start:      200,
+			stop:       200,
+			shardCount: 3,
+			expected:   nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expected, SplitInShards(c.start, c.stop, c.shardCount))
+		})
+	}
+}
+
+func Test_ProcessRowTransactionMetaNone(t *testing.T) {
+	// A protobuf row as Solana writes it, the first transaction without recorded inner
+	// instructions nor return data, the second with empty ones
+	meta := func(innerInstructionsNone, logMessagesNone, returnDataNone bool) []byte {
+		var out []byte
+		out = protowire.AppendTag(out, 2, protowire.VarintType)
+		out = protowire.AppendVarint(out, 5000)
+		for num, none := range map[protowire.Number]bool{10: innerInstructionsNone, 11: logMessagesNone, 15: returnDataNone} {
+			if none {
+				out = protowire.AppendTag(out, num, protowire.VarintType)
+				out = protowire.AppendVarint(out, 1)
+			}
+		}
+		return out
+	}
+
+	var block []byte
+	for _, trxMeta := range [][]byte{meta(true, false, true), meta(false, false, false)} {
+		var trx []byte
+		trx = protowire.AppendTag(trx, 2, protowire.BytesType)
+		trx = protowire.AppendBytes(trx, trxMeta)
+
+		block = protowire.AppendTag(block, 4, protowire.BytesType)
+		block = protowire.AppendBytes(block, trx)
+	}
+
+	reader := NewBigtableReader(nil, 0, &patches.Registry{}, zap.NewNop(), nil)
+	blk, _, err := reader.ProcessRow(bigtable.Row{"x": {{Row: "000000000000000a", Column: "x:proto", Value: append([]byte{0, 0, 0, 0}, block...)}}})
+	require.NoError(t, err)
+
+	require.Len(t, blk.Transactions, 2)
+	first, second := blk.Transactions[0].Meta, blk.Transactions[1].Meta
+	require.Equal(t, []bool{true, false, true}, []bool{first.InnerInstructionsNone, first.LogMessagesNone, first.ReturnDataNone})
+	require.Equal(t, []bool{false, false, false}, []bool{second.InnerInstructionsNone, second.LogMessagesNone, second.ReturnDataNone})
+	require.Equal(t, uint64(5000), second.Fee)
+}
+
+// newBigtableBlocks serves the blocks of slots from an in-memory Bigtable, each one linking to
+// the block before it, as uncompressed x:proto rows of the blocks table.
+func newBigtableBlocks(t *testing.T, slots ...uint64) *bigtable.Client {
+	ctx := context.Background()
+
+	server, err := bttest.NewServer("localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	conn, err := grpc.NewClient(server.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	admin, err := bigtable.NewAdminClient(ctx, "project", "instance", option.WithGRPCConn(conn))
+	require.NoError(t, err)
+	require.NoError(t, admin.CreateTable(ctx, "blocks"))
+	require.NoError(t, admin.CreateColumnFamily(ctx, "blocks", "x"))
+
+	client, err := bigtable.NewClient(ctx, "project", "instance", option.WithGRPCConn(conn))
+	require.NoError(t, err)
+
+	table := client.Open("blocks")
+	for i, slot := range slots {
+		parentSlot := slot - 1
+		if i > 0 {
+			parentSlot = slots[i-1]
+		}
+		data, err := proto.Marshal(&pbsolv1.Block{Blockhash: blockhash(slot), PreviousBlockhash: blockhash(parentSlot), ParentSlot: parentSlot})
+		require.NoError(t, err)
+
+		mutation := bigtable.NewMutation()
+		mutation.Set("x", "proto", bigtable.Now(), append([]byte{0, 0, 0, 0}, data...))
+		require.NoError(t, table.Apply(ctx, fmt.Sprintf("%016x", slot), mutation))
+	}
+	return client
+}
+
+type disabledTracer struct{}
+
+func (disabledTracer) Enabled() bool { return false }
+
+func Test_BigtableReadSkippedByPatches(t *testing.T) {
+	read := func(t *testing.T, chainPatches string) ([]uint64, error) {
+		registry, err := patches.Parse([]byte("version: 1\n" + chainPatches))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var slots []uint64
+		reader := NewBigtableReader(newBigtableBlocks(t, 10, 11, 12, 13, 14), 1, registry, zap.NewNop(), disabledTracer{})
+		err = reader.Read(ctx, 10, 13, func(block *pbsolv1.Block) error {
+			slots = append(slots, block.Slot)
+			return nil
+		})
+		return slots, err
+	}
+
+	t.Run("skipped range with previous blockhash patch", func(t *testing.T) {
+		slots, err := read(t, "skipped_slots:\n  - first: 11\n    last: 12\nprevious_blockhashes:\n  - blockhash: hash-13\n    previous_blockhash: hash-10\n")
+		require.NoError(t, err)
+		require.Equal(t, []uint64{10, 13, 14}, slots)
+	})
+
+	t.Run("skipped range without previous blockhash patch", func(t *testing.T) {
+		// Reading again from block 10 would never link block 13, the read fails instead
+		slots, err := read(t, "skipped_slots:\n  - first: 11\n    last: 12\n")
+		require.EqualError(t, err, "read blocks finished with a fatal error, last seen block 10 (hash-10): block 13 (hash-13) links to block 12 (hash-12) skipped by chain patches, its previous blockhash must be patched")
+		require.Equal(t, []uint64{10}, slots)
+	})
+}
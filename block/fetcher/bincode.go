@@ -0,0 +1,453 @@
+package fetcher
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	bin "github.com/streamingfast/binary"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+)
+
+// DecodeStoredConfirmedBlock decodes the legacy bincode `StoredConfirmedBlock` format found in
+// early Bigtable rows (the `x:bin` column), once decompressed:
+//
+//	struct StoredConfirmedBlock {
+//	    previous_blockhash: String,
+//	    blockhash: String,
+//	    parent_slot: u64,
+//	    transactions: Vec<StoredConfirmedBlockTransaction>,
+//	    rewards: Vec<StoredConfirmedBlockReward>,
+//	    block_time: Option<i64>,
+//	    block_height: Option<u64>, // absent on older rows
+//	}
+func DecodeStoredConfirmedBlock(data []byte) (*pbsol.Block, error) {
+	d := &bincodeDecoder{data: data}
+
+	previousBlockhash, err := d.readString()
+	if err != nil {
+		return nil, fmt.Errorf("reading previous blockhash: %w", err)
+	}
+	blockhash, err := d.readString()
+	if err != nil {
+		return nil, fmt.Errorf("reading blockhash: %w", err)
+	}
+	parentSlot, err := d.readUint64()
+	if err != nil {
+		return nil, fmt.Errorf("reading parent slot: %w", err)
+	}
+
+	trxCount, err := d.readLength()
+	if err != nil {
+		return nil, fmt.Errorf("reading transactions length: %w", err)
+	}
+	transactions := make([]*pbsol.ConfirmedTransaction, 0, trxCount)
+	for i := uint64(0); i < trxCount; i++ {
+		trx, err := d.readStoredTransaction()
+		if err != nil {
+			return nil, fmt.Errorf("reading transaction %d: %w", i, err)
+		}
+		transactions = append(transactions, trx)
+	}
+
+	rewards, err := d.readStoredRewards()
+	if err != nil {
+		return nil, fmt.Errorf("reading rewards: %w", err)
+	}
+
+	block := &pbsol.Block{
+		PreviousBlockhash: previousBlockhash,
+		Blockhash:         blockhash,
+		ParentSlot:        parentSlot,
+		Transactions:      transactions,
+		Rewards:           rewards,
+	}
+
+	hasBlockTime, err := d.readOption()
+	if err != nil {
+		return nil, fmt.Errorf("reading block time: %w", err)
+	}
+	if hasBlockTime {
+		blockTime, err := d.readUint64()
+		if err != nil {
+			return nil, fmt.Errorf("reading block time: %w", err)
+		}
+		block.BlockTime = &pbsol.UnixTimestamp{Timestamp: int64(blockTime)}
+	}
+
+	if d.remaining() == 0 {
+		return block, nil
+	}
+
+	hasBlockHeight, err := d.readOption()
+	if err != nil {
+		return nil, fmt.Errorf("reading block height: %w", err)
+	}
+	if hasBlockHeight {
+		blockHeight, err := d.readUint64()
+		if err != nil {
+			return nil, fmt.Errorf("reading block height: %w", err)
+		}
+		block.BlockHeight = &pbsol.BlockHeight{BlockHeight: blockHeight}
+	}
+
+	return block, nil
+}
+
+// bincodeDecoder reads bincode's default (fixed int, little endian) encoding. Solana's
+// `short_vec` fields use compact-u16 lengths instead, see readShortVecLength.
+type bincodeDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *bincodeDecoder) remaining() int {
+	return len(d.data) - d.pos
+}
+
+func (d *bincodeDecoder) readBytes(n uint64) ([]byte, error) {
+	if uint64(d.remaining()) < n {
+		return nil, fmt.Errorf("required [%d] bytes, remaining [%d]", n, d.remaining())
+	}
+	out := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return out, nil
+}
+
+func (d *bincodeDecoder) readByte() (byte, error) {
+	b, err := d.readBytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (d *bincodeDecoder) readUint64() (uint64, error) {
+	b, err := d.readBytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (d *bincodeDecoder) readLength() (uint64, error) {
+	l, err := d.readUint64()
+	if err != nil {
+		return 0, err
+	}
+	if l > uint64(d.remaining()) {
+		return 0, fmt.Errorf("length %d exceeds remaining [%d] bytes", l, d.remaining())
+	}
+	return l, nil
+}
+
+func (d *bincodeDecoder) readString() (string, error) {
+	l, err := d.readLength()
+	if err != nil {
+		return "", err
+	}
+	b, err := d.readBytes(l)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (d *bincodeDecoder) readOption() (bool, error) {
+	tag, err := d.readByte()
+	if err != nil {
+		return false, err
+	}
+	switch tag {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid option tag %d", tag)
+	}
+}
+
+// readShortVecLength reads a compact-u16 length. Every element taking at least a byte, a
+// length above the remaining bytes is an error, bounding what callers allocate from it.
+func (d *bincodeDecoder) readShortVecLength() (uint64, error) {
+	var length uint64
+	for i := 0; i < 3; i++ {
+		b, err := d.readByte()
+		if err != nil {
+			return 0, err
+		}
+		length |= uint64(b&0x7f) << (7 * i)
+		if b&0x80 == 0 {
+			if length > uint64(d.remaining()) {
+				return 0, fmt.Errorf("short_vec length %d exceeds remaining [%d] bytes", length, d.remaining())
+			}
+			return length, nil
+		}
+	}
+	return 0, fmt.Errorf("short_vec length overflows u16")
+}
+
+func (d *bincodeDecoder) readShortVecBytes() ([]byte, error) {
+	l, err := d.readShortVecLength()
+	if err != nil {
+		return nil, err
+	}
+	return d.readFixedCopy(l)
+}
+
+// readFixedCopy reads n bytes into a fresh slice so the decoded block never aliases the row buffer.
+func (d *bincodeDecoder) readFixedCopy(n uint64) ([]byte, error) {
+	b, err := d.readBytes(n)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, n)
+	copy(out, b)
+	return out, nil
+}
+
+func (d *bincodeDecoder) readShortVecOfFixed(size uint64) ([][]byte, error) {
+	l, err := d.readShortVecLength()
+	if err != nil {
+		return nil, err
+	}
+	if l*size > uint64(d.remaining()) {
+		return nil, fmt.Errorf("short_vec of %d elements of %d bytes exceeds remaining [%d] bytes", l, size, d.remaining())
+	}
+	out := make([][]byte, 0, l)
+	for i := uint64(0); i < l; i++ {
+		b, err := d.readFixedCopy(size)
+		if err != nil {
+			return nil, fmt.Errorf("reading element %d: %w", i, err)
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+func (d *bincodeDecoder) readStoredTransaction() (*pbsol.ConfirmedTransaction, error) {
+	trx, err := d.readTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("reading transaction: %w", err)
+	}
+
+	hasMeta, err := d.readOption()
+	if err != nil {
+		return nil, fmt.Errorf("reading meta: %w", err)
+	}
+
+	out := &pbsol.ConfirmedTransaction{Transaction: trx}
+	if hasMeta {
+		out.Meta, err = d.readStoredTransactionMeta()
+		if err != nil {
+			return nil, fmt.Errorf("reading meta: %w", err)
+		}
+	}
+	return out, nil
+}
+
+func (d *bincodeDecoder) readTransaction() (*pbsol.Transaction, error) {
+	signatures, err := d.readShortVecOfFixed(64)
+	if err != nil {
+		return nil, fmt.Errorf("reading signatures: %w", err)
+	}
+
+	message, err := d.readMessage()
+	if err != nil {
+		return nil, fmt.Errorf("reading message: %w", err)
+	}
+
+	return &pbsol.Transaction{
+		Signatures: signatures,
+		Message:    message,
+	}, nil
+}
+
+// readMessage reads a `VersionedMessage`, whose legacy form has no prefix byte, the first
+// byte being `num_required_signatures` which never has its high bit set.
+func (d *bincodeDecoder) readMessage() (*pbsol.Message, error) {
+	first, err := d.readByte()
+	if err != nil {
+		return nil, fmt.Errorf("reading message prefix: %w", err)
+	}
+
+	versioned := first&0x80 != 0
+	numRequiredSignatures := first
+	if versioned {
+		if version := first & 0x7f; version != 0 {
+			return nil, fmt.Errorf("unsupported message version %d", version)
+		}
+		numRequiredSignatures, err = d.readByte()
+		if err != nil {
+			return nil, fmt.Errorf("reading header: %w", err)
+		}
+	}
+
+	headerRest, err := d.readBytes(2)
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	accountKeys, err := d.readShortVecOfFixed(32)
+	if err != nil {
+		return nil, fmt.Errorf("reading account keys: %w", err)
+	}
+
+	recentBlockhash, err := d.readFixedCopy(32)
+	if err != nil {
+		return nil, fmt.Errorf("reading recent blockhash: %w", err)
+	}
+
+	instructionCount, err := d.readShortVecLength()
+	if err != nil {
+		return nil, fmt.Errorf("reading instructions length: %w", err)
+	}
+	instructions := make([]*pbsol.CompiledInstruction, 0, instructionCount)
+	for i := uint64(0); i < instructionCount; i++ {
+		programIdIndex, err := d.readByte()
+		if err != nil {
+			return nil, fmt.Errorf("reading instruction %d program id index: %w", i, err)
+		}
+		accounts, err := d.readShortVecBytes()
+		if err != nil {
+			return nil, fmt.Errorf("reading instruction %d accounts: %w", i, err)
+		}
+		data, err := d.readShortVecBytes()
+		if err != nil {
+			return nil, fmt.Errorf("reading instruction %d data: %w", i, err)
+		}
+		instructions = append(instructions, &pbsol.CompiledInstruction{
+			ProgramIdIndex: uint32(programIdIndex),
+			Accounts:       accounts,
+			Data:           data,
+		})
+	}
+
+	message := &pbsol.Message{
+		Header: &pbsol.MessageHeader{
+			NumRequiredSignatures:       uint32(numRequiredSignatures),
+			NumReadonlySignedAccounts:   uint32(headerRest[0]),
+			NumReadonlyUnsignedAccounts: uint32(headerRest[1]),
+		},
+		AccountKeys:     accountKeys,
+		RecentBlockhash: recentBlockhash,
+		Instructions:    instructions,
+		Versioned:       versioned,
+	}
+
+	if !versioned {
+		return message, nil
+	}
+
+	lookupCount, err := d.readShortVecLength()
+	if err != nil {
+		return nil, fmt.Errorf("reading address table lookups length: %w", err)
+	}
+	for i := uint64(0); i < lookupCount; i++ {
+		accountKey, err := d.readFixedCopy(32)
+		if err != nil {
+			return nil, fmt.Errorf("reading address table lookup %d account key: %w", i, err)
+		}
+		writableIndexes, err := d.readShortVecBytes()
+		if err != nil {
+			return nil, fmt.Errorf("reading address table lookup %d writable indexes: %w", i, err)
+		}
+		readonlyIndexes, err := d.readShortVecBytes()
+		if err != nil {
+			return nil, fmt.Errorf("reading address table lookup %d readonly indexes: %w", i, err)
+		}
+		message.AddressTableLookups = append(message.AddressTableLookups, &pbsol.MessageAddressTableLookup{
+			AccountKey:      accountKey,
+			WritableIndexes: writableIndexes,
+			ReadonlyIndexes: readonlyIndexes,
+		})
+	}
+
+	return message, nil
+}
+
+// readStoredTransactionMeta reads a `StoredConfirmedBlockTransactionStatusMeta`, only the
+// error, fee and balances were recorded in that format, the others being marked as none.
+func (d *bincodeDecoder) readStoredTransactionMeta() (*pbsol.TransactionStatusMeta, error) {
+	meta := &pbsol.TransactionStatusMeta{
+		InnerInstructionsNone: true,
+		LogMessagesNone:       true,
+		ReturnDataNone:        true,
+	}
+
+	hasErr, err := d.readOption()
+	if err != nil {
+		return nil, fmt.Errorf("reading err: %w", err)
+	}
+	if hasErr {
+		// The protobuf representation of an error is its bincode encoding, so we only need
+		// to find where it ends.
+		errDecoder := bin.NewDecoder(d.data[d.pos:])
+		if _, err := DecodeTransactionError(errDecoder); err != nil {
+			return nil, fmt.Errorf("reading err: %w", err)
+		}
+		errBytes, err := d.readFixedCopy(uint64(errDecoder.Position()))
+		if err != nil {
+			return nil, fmt.Errorf("reading err: %w", err)
+		}
+		meta.Err = &pbsol.TransactionError{Err: errBytes}
+	}
+
+	meta.Fee, err = d.readUint64()
+	if err != nil {
+		return nil, fmt.Errorf("reading fee: %w", err)
+	}
+
+	meta.PreBalances, err = d.readBalances()
+	if err != nil {
+		return nil, fmt.Errorf("reading pre balances: %w", err)
+	}
+
+	meta.PostBalances, err = d.readBalances()
+	if err != nil {
+		return nil, fmt.Errorf("reading post balances: %w", err)
+	}
+
+	return meta, nil
+}
+
+func (d *bincodeDecoder) readBalances() ([]uint64, error) {
+	l, err := d.readLength()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]uint64, 0, l)
+	for i := uint64(0); i < l; i++ {
+		balance, err := d.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, balance)
+	}
+	return out, nil
+}
+
+// readStoredRewards reads a `Vec<StoredConfirmedBlockReward>`, rewards only had a pubkey and
+// lamports in that format.
+func (d *bincodeDecoder) readStoredRewards() ([]*pbsol.Reward, error) {
+	l, err := d.readLength()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*pbsol.Reward, 0, l)
+	for i := uint64(0); i < l; i++ {
+		pubkey, err := d.readString()
+		if err != nil {
+			return nil, fmt.Errorf("reading reward %d pubkey: %w", i, err)
+		}
+		lamports, err := d.readUint64()
+		if err != nil {
+			return nil, fmt.Errorf("reading reward %d lamports: %w", i, err)
+		}
+		out = append(out, &pbsol.Reward{
+			Pubkey:   pubkey,
+			Lamports: int64(lamports),
+		})
+	}
+	return out, nil
+}
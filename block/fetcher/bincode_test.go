@@ -0,0 +1,164 @@
+package fetcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+type bincodeWriter struct {
+	bytes.Buffer
+}
+
+func (w *bincodeWriter) u64(v uint64) {
+	_ = binary.Write(&w.Buffer, binary.LittleEndian, v)
+}
+
+func (w *bincodeWriter) str(s string) {
+	w.u64(uint64(len(s)))
+	w.WriteString(s)
+}
+
+func (w *bincodeWriter) shortVec(b []byte) {
+	w.WriteByte(byte(len(b)))
+	w.Write(b)
+}
+
+func filled(size int, v byte) []byte {
+	return bytes.Repeat([]byte{v}, size)
+}
+
+func Test_DecodeStoredConfirmedBlock(t *testing.T) {
+	w := &bincodeWriter{}
+	w.str("previous")
+	w.str("current")
+	w.u64(41)
+
+	w.u64(2) // transactions
+
+	// Legacy transaction with an InstructionError{Custom: 6001} error
+	w.WriteByte(1) // signatures
+	w.Write(filled(64, 1))
+	w.Write([]byte{1, 0, 1}) // header
+	w.WriteByte(2)
+	w.Write(filled(32, 2))
+	w.Write(filled(32, 3))
+	w.Write(filled(32, 4)) // recent blockhash
+	w.WriteByte(1)         // instructions
+	w.WriteByte(1)
+	w.shortVec([]byte{0})
+	w.shortVec([]byte{0xaa, 0xbb})
+	w.WriteByte(1) // Some(meta)
+	w.WriteByte(1) // Some(err)
+	w.Write([]byte{8, 0, 0, 0, 0, 25, 0, 0, 0, 113, 23, 0, 0})
+	w.u64(5000)
+	w.u64(1)
+	w.u64(10)
+	w.u64(1)
+	w.u64(5)
+
+	// Versioned (v0) transaction without meta
+	w.WriteByte(1)
+	w.Write(filled(64, 5))
+	w.Write([]byte{0x80, 1, 0, 0})
+	w.WriteByte(0) // account keys
+	w.Write(filled(32, 6))
+	w.WriteByte(0) // instructions
+	w.WriteByte(1) // address table lookups
+	w.Write(filled(32, 7))
+	w.shortVec([]byte{1, 2})
+	w.shortVec([]byte{3})
+	w.WriteByte(0) // None(meta)
+
+	w.u64(1) // rewards
+	w.str("pubkey")
+	_ = binary.Write(&w.Buffer, binary.LittleEndian, int64(-3))
+
+	w.WriteByte(1) // Some(block_time)
+	w.u64(1600000000)
+	// block_height absent, as in older rows
+
+	block, err := DecodeStoredConfirmedBlock(w.Bytes())
+	require.NoError(t, err)
+
+	expected := &pbsol.Block{
+		PreviousBlockhash: "previous",
+		Blockhash:         "current",
+		ParentSlot:        41,
+		Transactions: []*pbsol.ConfirmedTransaction{
+			{
+				Transaction: &pbsol.Transaction{
+					Signatures: [][]byte{filled(64, 1)},
+					Message: &pbsol.Message{
+						Header:          &pbsol.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+						AccountKeys:     [][]byte{filled(32, 2), filled(32, 3)},
+						RecentBlockhash: filled(32, 4),
+						Instructions: []*pbsol.CompiledInstruction{
+							{ProgramIdIndex: 1, Accounts: []byte{0}, Data: []byte{0xaa, 0xbb}},
+						},
+					},
+				},
+				Meta: &pbsol.TransactionStatusMeta{
+					Err:                   &pbsol.TransactionError{Err: []byte{8, 0, 0, 0, 0, 25, 0, 0, 0, 113, 23, 0, 0}},
+					Fee:                   5000,
+					PreBalances:           []uint64{10},
+					PostBalances:          []uint64{5},
+					InnerInstructionsNone: true,
+					LogMessagesNone:       true,
+					ReturnDataNone:        true,
+				},
+			},
+			{
+				Transaction: &pbsol.Transaction{
+					Signatures: [][]byte{filled(64, 5)},
+					Message: &pbsol.Message{
+						Header:          &pbsol.MessageHeader{NumRequiredSignatures: 1},
+						AccountKeys:     [][]byte{},
+						RecentBlockhash: filled(32, 6),
+						Instructions:    []*pbsol.CompiledInstruction{},
+						Versioned:       true,
+						AddressTableLookups: []*pbsol.MessageAddressTableLookup{
+							{AccountKey: filled(32, 7), WritableIndexes: []byte{1, 2}, ReadonlyIndexes: []byte{3}},
+						},
+					},
+				},
+			},
+		},
+		Rewards:   []*pbsol.Reward{{Pubkey: "pubkey", Lamports: -3}},
+		BlockTime: &pbsol.UnixTimestamp{Timestamp: 1600000000},
+	}
+
+	require.True(t, proto.Equal(expected, block), "expected %v, got %v", expected, block)
+}
+
+func Test_DecodeStoredConfirmedBlock_Truncated(t *testing.T) {
+	w := &bincodeWriter{}
+	w.str("previous")
+	w.str("current")
+	w.u64(41)
+	w.u64(1) // claims one transaction but none follows
+
+	_, err := DecodeStoredConfirmedBlock(w.Bytes())
+	require.Error(t, err)
+}
+
+func Test_ReadShortVecLength_Bounded(t *testing.T) {
+	// 0xff 0xff 0x03 is the largest compact-u16, 65535, with two bytes following it
+	d := &bincodeDecoder{data: []byte{0xff, 0xff, 0x03, 0x01, 0x02}}
+	_, err := d.readShortVecLength()
+	require.EqualError(t, err, "short_vec length 65535 exceeds remaining [2] bytes")
+
+	// 2 signatures of 64 bytes claimed, only 64 bytes following
+	d = &bincodeDecoder{data: append([]byte{0x02}, make([]byte, 64)...)}
+	_, err = d.readShortVecOfFixed(64)
+	require.EqualError(t, err, "short_vec of 2 elements of 64 bytes exceeds remaining [64] bytes")
+
+	d = &bincodeDecoder{data: []byte{0x02, 0x01, 0x02}}
+	b, err := d.readShortVecBytes()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02}, b)
+}
@@ -0,0 +1,97 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"go.uber.org/zap"
+)
+
+// needsCommission tells if reward should carry a commission, only voting and staking rewards
+// are paid out of a vote account.
+func needsCommission(reward *pbsol.Reward) bool {
+	return reward.Commission == "" && (reward.RewardType == pbsol.RewardType_Voting || reward.RewardType == pbsol.RewardType_Staking)
+}
+
+// BackfillRewardsCommission sets the commission of rewards lacking one to the commission of the
+// reference reward with the same pubkey, type and lamports, reference being the rewards of the
+// same block from another source. It returns how many rewards were filled.
+func BackfillRewardsCommission(rewards []*pbsol.Reward, reference []*pbsol.Reward) (filled int) {
+	type rewardKey struct {
+		pubkey     string
+		rewardType pbsol.RewardType
+		lamports   int64
+	}
+
+	commissions := map[rewardKey]string{}
+	for _, reward := range reference {
+		if reward.Commission != "" {
+			commissions[rewardKey{reward.Pubkey, reward.RewardType, reward.Lamports}] = reward.Commission
+		}
+	}
+
+	for _, reward := range rewards {
+		if !needsCommission(reward) {
+			continue
+		}
+		if commission, found := commissions[rewardKey{reward.Pubkey, reward.RewardType, reward.Lamports}]; found {
+			reward.Commission = commission
+			filled++
+		}
+	}
+	return filled
+}
+
+// CommissionBackfiller fills the commission of blocks fetched through RPC before the converter
+// kept it, getting the block's rewards, without its transactions, from an RPC endpoint.
+type CommissionBackfiller struct {
+	client *rpc.Client
+	logger *zap.Logger
+}
+
+func NewCommissionBackfiller(client *rpc.Client, logger *zap.Logger) *CommissionBackfiller {
+	return &CommissionBackfiller{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Backfill fills the commission of block's rewards, the endpoint only being called when some
+// reward lacks one. It returns how many rewards were filled.
+func (b *CommissionBackfiller) Backfill(ctx context.Context, block *pbsol.Block) (int, error) {
+	missing := 0
+	for _, reward := range block.Rewards {
+		if needsCommission(reward) {
+			missing++
+		}
+	}
+	if missing == 0 {
+		return 0, nil
+	}
+
+	withRewards := true
+	maxSupportedTransactionVersion := DefaultRequestConfig.MaxSupportedTransactionVersion
+	result, err := b.client.GetBlockWithOpts(ctx, block.Slot, &rpc.GetBlockOpts{
+		TransactionDetails:             rpc.TransactionDetailsNone,
+		Rewards:                        &withRewards,
+		Commitment:                     rpc.CommitmentFinalized,
+		MaxSupportedTransactionVersion: &maxSupportedTransactionVersion,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("getting rewards of block %d: %w", block.Slot, err)
+	}
+
+	converter := &rpcConverter{policy: ConversionPolicyUnknown, logger: b.logger}
+	reference, err := converter.toPBReward("rewards", result.Rewards)
+	if err != nil {
+		return 0, fmt.Errorf("converting rewards of block %d: %w", block.Slot, err)
+	}
+
+	filled := BackfillRewardsCommission(block.Rewards, reference)
+	if filled < missing {
+		b.logger.Warn("rewards without commission left in block", zap.Uint64("slot", block.Slot), zap.Int("missing", missing-filled))
+	}
+	return filled, nil
+}
@@ -0,0 +1,139 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloud.google.com/go/bigtable"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/streamingfast/firehose-solana/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const testRewardsJSON = `[
+	{"pubkey": "SysvarRent111111111111111111111111111111111", "lamports": 20, "postBalance": 120, "rewardType": "Voting", "commission": 10},
+	{"pubkey": "SysvarC1ock11111111111111111111111111111111", "lamports": 30, "postBalance": 130, "rewardType": "Staking", "commission": 0},
+	{"pubkey": "11111111111111111111111111111111", "lamports": 5000, "postBalance": 15000, "rewardType": "Fee"}
+]`
+
+// bigtableRewards are testRewardsJSON's rewards the way Bigtable stores them, as protobuf
+// with a decimal commission.
+func bigtableRewards(t *testing.T) []*pbsol.Reward {
+	data, err := proto.Marshal(&pbsol.Rewards{Rewards: []*pbsol.Reward{
+		{Pubkey: "SysvarRent111111111111111111111111111111111", Lamports: 20, PostBalance: 120, RewardType: pbsol.RewardType_Voting, Commission: "10"},
+		{Pubkey: "SysvarC1ock11111111111111111111111111111111", Lamports: 30, PostBalance: 130, RewardType: pbsol.RewardType_Staking, Commission: "0"},
+		{Pubkey: "11111111111111111111111111111111", Lamports: 5000, PostBalance: 15000, RewardType: pbsol.RewardType_Fee},
+	}})
+	require.NoError(t, err)
+
+	rewards := &pbsol.Rewards{}
+	require.NoError(t, proto.Unmarshal(data, rewards))
+	return rewards.Rewards
+}
+
+// Test_RPCRewardsMatchBigtable decodes the rewards of Bigtable rows, a protobuf `x:proto` one
+// with commissions and a legacy bincode `x:bin` one without, the RPC blocks of the same slots
+// having to hold the same rewards once Bigtable ones are sorted.
+func Test_RPCRewardsMatchBigtable(t *testing.T) {
+	for _, c := range []struct {
+		slot    uint64
+		column  string
+		rowFile string
+	}{
+		{200, "x:proto", "bigtable-row.bin"},
+		{90, "x:bin", "bigtable-row-bincode.bin"},
+	} {
+		t.Run(c.column, func(t *testing.T) {
+			dir := filepath.Join("testdata/compare", fmt.Sprint(c.slot))
+			row, err := os.ReadFile(filepath.Join(dir, c.rowFile))
+			require.NoError(t, err)
+			reader := NewBigtableReader(nil, 0, &patches.Registry{}, zap.NewNop(), nil)
+			fromBigtable, _, err := reader.ProcessRow(bigtable.Row{"x": {{Row: fmt.Sprintf("%016x", c.slot), Column: c.column, Value: row}}})
+			require.NoError(t, err)
+			require.NotEmpty(t, fromBigtable.Rewards)
+
+			rpcData, err := os.ReadFile(filepath.Join(dir, "rpc.json"))
+			require.NoError(t, err)
+			result := &rpc.GetBlockResult{}
+			require.NoError(t, json.Unmarshal(rpcData, result))
+			block, err := blockFromBlockResult(c.slot, c.slot, result, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+			require.NoError(t, err)
+			fromRPC := &pbsol.Block{}
+			require.NoError(t, block.Payload.UnmarshalTo(fromRPC))
+
+			SortRewards(fromBigtable.Rewards)
+			require.Len(t, fromRPC.Rewards, len(fromBigtable.Rewards))
+			for i, reward := range fromRPC.Rewards {
+				require.True(t, proto.Equal(fromBigtable.Rewards[i], reward), "reward %d: expected %s, got %s", i, fromBigtable.Rewards[i], reward)
+			}
+		})
+	}
+}
+
+func Test_BackfillRewardsCommission(t *testing.T) {
+	rewards := bigtableRewards(t)
+	for _, reward := range rewards {
+		reward.Commission = ""
+	}
+	// A reward of another block for the same vote account must not be matched
+	rewards = append(rewards, &pbsol.Reward{Pubkey: "SysvarRent111111111111111111111111111111111", Lamports: 21, RewardType: pbsol.RewardType_Voting})
+
+	filled := BackfillRewardsCommission(rewards, bigtableRewards(t))
+	require.Equal(t, 2, filled)
+	require.Equal(t, []string{"10", "0", "", ""}, []string{rewards[0].Commission, rewards[1].Commission, rewards[2].Commission, rewards[3].Commission})
+}
+
+func Test_CommissionBackfiller(t *testing.T) {
+	type rpcRequest struct {
+		ID     any    `json:"id"`
+		Method string `json:"method"`
+		Params []any  `json:"params"`
+	}
+
+	// Requests are checked by the test, the handler running in the server's goroutines
+	requests := make(chan rpcRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests <- req
+
+		id, _ := json.Marshal(req.ID)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"blockhash":"11111111111111111111111111111111","previousBlockhash":"11111111111111111111111111111111","parentSlot":9,"rewards":%s}}`, id, testRewardsJSON)
+	}))
+	defer server.Close()
+
+	backfiller := NewCommissionBackfiller(rpc.New(server.URL), zap.NewNop())
+
+	block := &pbsol.Block{Slot: 10, Rewards: bigtableRewards(t)}
+	for _, reward := range block.Rewards {
+		reward.Commission = ""
+	}
+	filled, err := backfiller.Backfill(context.Background(), block)
+	require.NoError(t, err)
+	require.Equal(t, 2, filled)
+	require.Len(t, requests, 1)
+	req := <-requests
+	require.Equal(t, "getBlock", req.Method)
+	require.Equal(t, float64(10), req.Params[0])
+	require.Equal(t, "none", req.Params[1].(map[string]any)["transactionDetails"])
+	for i, expected := range bigtableRewards(t) {
+		require.True(t, proto.Equal(expected, block.Rewards[i]), "reward %d", i)
+	}
+
+	filled, err = backfiller.Backfill(context.Background(), block)
+	require.NoError(t, err)
+	require.Equal(t, 0, filled)
+	require.Len(t, requests, 0, "a block with every commission set needs no call")
+}
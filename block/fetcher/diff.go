@@ -0,0 +1,188 @@
+package fetcher
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/mr-tron/base58"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxDiffValueLength bounds the length of the values rendered in a FieldDiff, messages present
+// on one side only being rendered whole.
+const maxDiffValueLength = 200
+
+// FieldDiff is a difference between two messages at a field path, like
+// `transactions[2].meta.log_messages[0]`. Reference and Candidate are the rendered values,
+// `<unset>` for a field or list element absent from one of them.
+type FieldDiff struct {
+	Path      string `json:"path"`
+	Reference string `json:"reference"`
+	Candidate string `json:"candidate"`
+}
+
+func (d *FieldDiff) String() string {
+	return fmt.Sprintf("%s: %s != %s", d.Path, d.Reference, d.Candidate)
+}
+
+var pathIndexRegex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// Field returns the path of the differing field without list indexes and map keys, like
+// `transactions.meta.log_messages`, which groups the differences of a same field.
+func (d *FieldDiff) Field() string {
+	return pathIndexRegex.ReplaceAllString(d.Path, "")
+}
+
+// DiffMessages returns the field-level differences of candidate relative to reference, both
+// being of the same message type, nil when they are equal. Bytes are rendered in base58, like
+// Solana renders hashes and keys. Unknown fields are ignored.
+func DiffMessages(reference, candidate proto.Message) []*FieldDiff {
+	var diffs []*FieldDiff
+	diffMessage("", reference.ProtoReflect(), candidate.ProtoReflect(), &diffs)
+	return diffs
+}
+
+func diffMessage(path string, reference, candidate protoreflect.Message, diffs *[]*FieldDiff) {
+	fields := reference.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldPath := string(field.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+
+		switch {
+		case field.IsList():
+			diffList(fieldPath, field, reference.Get(field).List(), candidate.Get(field).List(), diffs)
+		case field.IsMap():
+			diffMap(fieldPath, field, reference.Get(field).Map(), candidate.Get(field).Map(), diffs)
+		case field.Message() != nil:
+			referenceSet, candidateSet := reference.Has(field), candidate.Has(field)
+			if referenceSet != candidateSet {
+				*diffs = append(*diffs, &FieldDiff{Path: fieldPath, Reference: presentValue(field, reference, referenceSet), Candidate: presentValue(field, candidate, candidateSet)})
+				continue
+			}
+			if referenceSet {
+				diffMessage(fieldPath, reference.Get(field).Message(), candidate.Get(field).Message(), diffs)
+			}
+		default:
+			// Optional scalars being unset differs from them being set to their default
+			if field.HasPresence() && reference.Has(field) != candidate.Has(field) {
+				*diffs = append(*diffs, &FieldDiff{Path: fieldPath, Reference: presentValue(field, reference, reference.Has(field)), Candidate: presentValue(field, candidate, candidate.Has(field))})
+				continue
+			}
+			diffValue(fieldPath, field, reference.Get(field), candidate.Get(field), diffs)
+		}
+	}
+}
+
+func diffList(path string, field protoreflect.FieldDescriptor, reference, candidate protoreflect.List, diffs *[]*FieldDiff) {
+	for i := 0; i < max(reference.Len(), candidate.Len()); i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= candidate.Len():
+			*diffs = append(*diffs, &FieldDiff{Path: elementPath, Reference: formatValue(field, reference.Get(i)), Candidate: "<unset>"})
+		case i >= reference.Len():
+			*diffs = append(*diffs, &FieldDiff{Path: elementPath, Reference: "<unset>", Candidate: formatValue(field, candidate.Get(i))})
+		case field.Message() != nil:
+			diffMessage(elementPath, reference.Get(i).Message(), candidate.Get(i).Message(), diffs)
+		default:
+			diffValue(elementPath, field, reference.Get(i), candidate.Get(i), diffs)
+		}
+	}
+}
+
+func diffMap(path string, field protoreflect.FieldDescriptor, reference, candidate protoreflect.Map, diffs *[]*FieldDiff) {
+	valueField := field.MapValue()
+	reference.Range(func(key protoreflect.MapKey, referenceValue protoreflect.Value) bool {
+		entryPath := fmt.Sprintf("%s[%v]", path, key.Interface())
+		candidateValue := candidate.Get(key)
+		switch {
+		case !candidate.Has(key):
+			*diffs = append(*diffs, &FieldDiff{Path: entryPath, Reference: formatValue(valueField, referenceValue), Candidate: "<unset>"})
+		case valueField.Message() != nil:
+			diffMessage(entryPath, referenceValue.Message(), candidateValue.Message(), diffs)
+		default:
+			diffValue(entryPath, valueField, referenceValue, candidateValue, diffs)
+		}
+		return true
+	})
+	candidate.Range(func(key protoreflect.MapKey, candidateValue protoreflect.Value) bool {
+		if !reference.Has(key) {
+			*diffs = append(*diffs, &FieldDiff{Path: fmt.Sprintf("%s[%v]", path, key.Interface()), Reference: "<unset>", Candidate: formatValue(valueField, candidateValue)})
+		}
+		return true
+	})
+}
+
+func diffValue(path string, field protoreflect.FieldDescriptor, reference, candidate protoreflect.Value, diffs *[]*FieldDiff) {
+	equal := reference.Equal(candidate)
+	if field.Kind() == protoreflect.BytesKind {
+		equal = bytes.Equal(reference.Bytes(), candidate.Bytes())
+	}
+	if !equal {
+		*diffs = append(*diffs, &FieldDiff{Path: path, Reference: formatValue(field, reference), Candidate: formatValue(field, candidate)})
+	}
+}
+
+func presentValue(field protoreflect.FieldDescriptor, message protoreflect.Message, set bool) string {
+	if !set {
+		return "<unset>"
+	}
+	return formatValue(field, message.Get(field))
+}
+
+func formatValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	var out string
+	switch {
+	case field.Message() != nil:
+		out = "{" + prototext.MarshalOptions{}.Format(value.Message().Interface()) + "}"
+	case field.Kind() == protoreflect.BytesKind:
+		out = base58.Encode(value.Bytes())
+	case field.Kind() == protoreflect.StringKind:
+		out = fmt.Sprintf("%q", value.String())
+	case field.Kind() == protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			out = string(enumValue.Name())
+		} else {
+			out = fmt.Sprintf("%d", value.Enum())
+		}
+	default:
+		out = fmt.Sprintf("%v", value.Interface())
+	}
+
+	if len(out) > maxDiffValueLength {
+		out = out[:maxDiffValueLength] + "..."
+	}
+	return out
+}
+
+// FilterDiffs returns the diffs whose Field is not one of ignoredFields.
+func FilterDiffs(diffs []*FieldDiff, ignoredFields []string) []*FieldDiff {
+	if len(ignoredFields) == 0 {
+		return diffs
+	}
+
+	var out []*FieldDiff
+	for _, diff := range diffs {
+		if !slices.Contains(ignoredFields, diff.Field()) {
+			out = append(out, diff)
+		}
+	}
+	return out
+}
+
+// NormalizeForCompare sorts block rewards by lamports, like the RPC conversion and
+// upgrade-merged-blocks do, so that blocks written before rewards were sorted don't differ on
+// their order only. Rewards of equal lamports are ordered by pubkey.
+func NormalizeForCompare(block *pbsol.Block) {
+	slices.SortStableFunc(block.Rewards, func(a, b *pbsol.Reward) int {
+		return cmp.Or(cmp.Compare(a.Lamports, b.Lamports), cmp.Compare(a.Pubkey, b.Pubkey))
+	})
+}
@@ -0,0 +1,113 @@
+package fetcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"cloud.google.com/go/bigtable"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/streamingfast/firehose-solana/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+func Test_DiffMessages(t *testing.T) {
+	computeUnits := uint64(10)
+	reference := &pbsol.Block{
+		Blockhash: "a",
+		Transactions: []*pbsol.ConfirmedTransaction{
+			{Meta: &pbsol.TransactionStatusMeta{Fee: 1, LogMessages: []string{"x", "y"}, ComputeUnitsConsumed: &computeUnits}},
+		},
+		Rewards: []*pbsol.Reward{{Pubkey: "p", RewardType: pbsol.RewardType_Voting, Commission: "10"}},
+	}
+	candidate := &pbsol.Block{
+		Blockhash: "a",
+		Transactions: []*pbsol.ConfirmedTransaction{
+			{Meta: &pbsol.TransactionStatusMeta{Fee: 2, LogMessages: []string{"x"}, ReturnData: &pbsol.ReturnData{Data: []byte{1}}}},
+		},
+		Rewards:   []*pbsol.Reward{{Pubkey: "p", RewardType: pbsol.RewardType_Staking}},
+		BlockTime: &pbsol.UnixTimestamp{Timestamp: 1},
+	}
+
+	var actual []string
+	for _, diff := range DiffMessages(reference, candidate) {
+		actual = append(actual, diff.String())
+	}
+	require.Equal(t, []string{
+		`transactions[0].meta.fee: 1 != 2`,
+		`transactions[0].meta.log_messages[1]: "y" != <unset>`,
+		`transactions[0].meta.return_data: <unset> != {data:"\x01"}`,
+		`transactions[0].meta.compute_units_consumed: 10 != <unset>`,
+		`rewards[0].reward_type: Voting != Staking`,
+		`rewards[0].commission: "10" != ""`,
+		`block_time: <unset> != {timestamp:1}`,
+	}, actual)
+
+	require.Nil(t, DiffMessages(reference, reference))
+
+	filtered := FilterDiffs(DiffMessages(reference, candidate), []string{"transactions.meta.log_messages", "rewards.commission"})
+	require.Len(t, filtered, 5)
+	require.Equal(t, "transactions.meta.return_data", filtered[1].Field())
+}
+
+// Test_CompareSources converts each slot of testdata/compare from its RPC getBlock result and
+// its Bigtable row, both sources of a same slot being expected to give the same block once
+// normalized, Bigtable keeping the rewards in the validator's order. A bigtable-row.bin file
+// holds an `x:proto` row, a bigtable-row-bincode.bin one a legacy `x:bin` row.
+func Test_CompareSources(t *testing.T) {
+	dirs, err := os.ReadDir("testdata/compare")
+	require.NoError(t, err)
+	require.NotEmpty(t, dirs)
+
+	for _, dir := range dirs {
+		t.Run(dir.Name(), func(t *testing.T) {
+			slot, err := strconv.ParseUint(dir.Name(), 10, 64)
+			require.NoError(t, err)
+
+			rpcData, err := os.ReadFile(filepath.Join("testdata/compare", dir.Name(), "rpc.json"))
+			require.NoError(t, err)
+			result := &rpc.GetBlockResult{}
+			require.NoError(t, json.Unmarshal(rpcData, result))
+
+			rpcBlock, err := blockFromBlockResult(slot, slot, result, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+			require.NoError(t, err)
+			fromRPC := &pbsol.Block{}
+			require.NoError(t, rpcBlock.Payload.UnmarshalTo(fromRPC))
+
+			column, rowFile := "x:proto", "bigtable-row.bin"
+			if _, err := os.Stat(filepath.Join("testdata/compare", dir.Name(), "bigtable-row-bincode.bin")); err == nil {
+				column, rowFile = "x:bin", "bigtable-row-bincode.bin"
+			}
+			row, err := os.ReadFile(filepath.Join("testdata/compare", dir.Name(), rowFile))
+			require.NoError(t, err)
+			reader := NewBigtableReader(nil, 0, &patches.Registry{}, zap.NewNop(), nil)
+			fromBigtable, _, err := reader.ProcessRow(bigtable.Row{"x": {{Row: fmt.Sprintf("%016x", slot), Column: column, Value: row}}})
+			require.NoError(t, err)
+
+			NormalizeForCompare(fromBigtable)
+			NormalizeForCompare(fromRPC)
+			diffs := DiffMessages(fromBigtable, fromRPC)
+			require.Empty(t, diffs, "rpc block differs from bigtable block: %s", diffs)
+		})
+	}
+}
+
+func Test_NormalizeForCompare(t *testing.T) {
+	block := &pbsol.Block{Rewards: []*pbsol.Reward{
+		{Pubkey: "c", Lamports: 2500},
+		{Pubkey: "b", Lamports: 1000},
+		{Pubkey: "a", Lamports: 1000},
+	}}
+	NormalizeForCompare(block)
+
+	var pubkeys []string
+	for _, reward := range block.Rewards {
+		pubkeys = append(pubkeys, reward.Pubkey)
+	}
+	require.Equal(t, []string{"a", "b", "c"}, pubkeys)
+}
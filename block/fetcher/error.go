@@ -2,7 +2,9 @@ package fetcher
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"math"
 
 	bin "github.com/streamingfast/binary"
 )
@@ -53,6 +55,11 @@ const (
 	TrxErr_UnbalancedTransaction
 )
 
+// TrxErr_Unknown is the reserved code, 0xffffffff once encoded, of the transaction errors this
+// version does not know, a variant added by a newer validator for example. Its detail is an
+// UnknownError keeping the error as returned by the RPC.
+const TrxErr_Unknown TrxErrCode = -1
+
 var trxErrorMap = map[string]TrxErrCode{
 	"AccountInUse":                          TrxErr_AccountInUse,
 	"AccountLoadedTwice":                    TrxErr_AccountLoadedTwice,
@@ -98,58 +105,160 @@ type TransactionError struct {
 	detail errDetail
 }
 
-func MustNewTransactionError(e any) *TransactionError {
+// NewTransactionError converts the JSON representation of a Solana `TransactionError`, as
+// returned by the RPC, e being nil when the transaction succeeded. Errors it does not know are
+// kept as TrxErr_Unknown, or InstructionError_Unknown for an instruction error, so it only
+// fails if e can't be encoded back to JSON.
+func NewTransactionError(e any) (*TransactionError, error) {
 	if e == nil {
-		return nil
+		return nil, nil
+	}
+
+	trxErr, err := newKnownTransactionError(e)
+	if err == nil {
+		return trxErr, nil
 	}
 
+	unknownErr, jsonErr := newUnknownError(e)
+	if jsonErr != nil {
+		return nil, fmt.Errorf("%w, keeping it as unknown: %s", err, jsonErr)
+	}
+	return &TransactionError{TrxErr_Unknown, unknownErr}, nil
+}
+
+func newKnownTransactionError(e any) (*TransactionError, error) {
+
 	if errorName, ok := e.(string); ok {
 		if errorCode, ok := trxErrorMap[errorName]; ok {
-			return &TransactionError{errorCode, nil}
+			return &TransactionError{errorCode, nil}, nil
 		}
-		panic(fmt.Errorf("unknown error name: %s", errorName))
+		return nil, fmt.Errorf("unknown error name: %s", errorName)
 	}
 
 	if mapErr, ok := e.(map[string]interface{}); ok {
 		if len(mapErr) != 1 {
-			panic(fmt.Errorf("unknown error map: %v", mapErr))
+			return nil, fmt.Errorf("unknown error map: %v", mapErr)
 		}
 		for errorName, detailMap := range mapErr {
-			if errorCode, ok := trxErrorMap[errorName]; ok {
-				var errorDetail errDetail
-
-				//	//8 0 0 0 3 25 0 0 0 113 23 0 0
-				//	//"err":{"InstructionError":[3,{"Custom":22}]}
-				//
-				//	//[1 0 0 0]
-				//	//"err":"AccountInUse"
-				//
-				//	//8 0 0 0 -> TransactionError.InstructionError
-				//	//3 -> instruction index
-				//	//25 0 0 0 -> InstructionError.Custom
-				//	//113 23 0 0 -> u32 error code
-
-				switch errorCode {
-				case TrxErr_InstructionError:
-					errorDetail = MustNewInstructionError(detailMap)
-				case TrxErr_DuplicateInstruction:
-					errorDetail = MustNewDuplicateInstructionError(detailMap)
-				case TrxErr_InsufficientFundsForRent:
-					errorDetail = MustNewInsufficientFundsForRentError(detailMap)
-				case TrxErr_ProgramExecutionTemporarilyRestricted:
-					errorDetail = MustNewProgramExecutionTemporarilyRestrictedError(detailMap)
-				default:
-					panic(fmt.Errorf("unknown error code: %d", errorCode))
-				}
-
-				return &TransactionError{errorCode, errorDetail}
+			errorCode, ok := trxErrorMap[errorName]
+			if !ok {
+				return nil, fmt.Errorf("unknown error name: %s", errorName)
+			}
+
+			//	//8 0 0 0 3 25 0 0 0 113 23 0 0
+			//	//"err":{"InstructionError":[3,{"Custom":22}]}
+			//
+			//	//[1 0 0 0]
+			//	//"err":"AccountInUse"
+			//
+			//	//8 0 0 0 -> TransactionError.InstructionError
+			//	//3 -> instruction index
+			//	//25 0 0 0 -> InstructionError.Custom
+			//	//113 23 0 0 -> u32 error code
+
+			var errorDetail errDetail
+			var err error
+			switch errorCode {
+			case TrxErr_InstructionError:
+				errorDetail, err = NewInstructionError(detailMap)
+			case TrxErr_DuplicateInstruction:
+				errorDetail, err = NewDuplicateInstructionError(detailMap)
+			case TrxErr_InsufficientFundsForRent:
+				errorDetail, err = NewInsufficientFundsForRentError(detailMap)
+			case TrxErr_ProgramExecutionTemporarilyRestricted:
+				errorDetail, err = NewProgramExecutionTemporarilyRestrictedError(detailMap)
+			default:
+				return nil, fmt.Errorf("unexpected details for error %s", errorName)
 			}
-			panic(fmt.Errorf("unknown error name: %s", errorName))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", errorName, err)
+			}
+
+			return &TransactionError{errorCode, errorDetail}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown error type: %T", e)
+}
+
+// DecodeTransactionError is the inverse of TransactionError.Encode, reading the bincode
+// representation of a Solana `TransactionError`.
+func DecodeTransactionError(decoder *bin.Decoder) (*TransactionError, error) {
+	code, err := decoder.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode error code: %w", err)
+	}
+
+	trxErr := &TransactionError{TrxErrCode: TrxErrCode(code)}
+	switch trxErr.TrxErrCode {
+	case TrxErr_InstructionError:
+		instructionErr, err := DecodeInstructionError(decoder)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode instruction error: %w", err)
+		}
+		trxErr.detail = instructionErr
+	case TrxErr_DuplicateInstruction:
+		index, err := decoder.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode duplicate instruction index: %w", err)
+		}
+		trxErr.detail = &DuplicateInstructionError{duplicateInstructionIndex: index}
+	case TrxErr_InsufficientFundsForRent:
+		accountIndex, err := decoder.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode account index: %w", err)
+		}
+		trxErr.detail = &InsufficientFundsForRentError{AccountIndex: accountIndex}
+	case TrxErr_ProgramExecutionTemporarilyRestricted:
+		accountIndex, err := decoder.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode account index: %w", err)
+		}
+		trxErr.detail = &ProgramExecutionTemporarilyRestrictedError{AccountIndex: accountIndex}
+	case TrxErr_Unknown:
+		unknownErr, err := decodeUnknownError(decoder)
+		if err != nil {
+			return nil, err
+		}
+		trxErr.detail = unknownErr
+	default:
+		if trxErr.TrxErrCode < 0 || trxErr.TrxErrCode > TrxErr_UnbalancedTransaction {
+			return nil, fmt.Errorf("unknown error code: %d", code)
 		}
-		//we should never get here since we checked the length of the map and we are exiting only one element
 	}
 
-	panic(fmt.Errorf("unknown error type: %T", e))
+	return trxErr, nil
+}
+
+// UnknownError is the detail of an error variant this version does not know: its JSON
+// representation as returned by the RPC, keys being sorted. It is encoded as a string, like the
+// BorshIoError message.
+type UnknownError struct {
+	JSON string
+}
+
+func newUnknownError(e any) (UnknownError, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return UnknownError{}, err
+	}
+	return UnknownError{JSON: string(data)}, nil
+}
+
+func decodeUnknownError(decoder *bin.Decoder) (UnknownError, error) {
+	data, err := ReadString(decoder)
+	if err != nil {
+		return UnknownError{}, fmt.Errorf("unable to decode unknown error: %w", err)
+	}
+	return UnknownError{JSON: data}, nil
+}
+
+func (e UnknownError) Encode(encoder *bin.Encoder) error {
+	err := WriteString(e.JSON, encoder)
+	if err != nil {
+		return fmt.Errorf("unable to encode unknown error: %w", err)
+	}
+	return nil
 }
 
 type DuplicateInstructionError struct {
@@ -180,54 +289,57 @@ func (e *InsufficientFundsForRentError) Encode(encoder *bin.Encoder) error {
 	return nil
 }
 
-func MustNewProgramExecutionTemporarilyRestrictedError(e any) *ProgramExecutionTemporarilyRestrictedError {
-	mapE, ok := e.(map[string]interface{})
-	if !ok {
-		panic(fmt.Errorf("expected map[string]interface{}, got: %T", e))
-	}
-
-	accountIndex, ok := mapE["account_index"]
-	if !ok {
-		panic(fmt.Errorf("expected account_index, got: %T", e))
+func NewProgramExecutionTemporarilyRestrictedError(e any) (*ProgramExecutionTemporarilyRestrictedError, error) {
+	accountIndex, err := accountIndexDetail(e)
+	if err != nil {
+		return nil, err
 	}
+	return &ProgramExecutionTemporarilyRestrictedError{AccountIndex: accountIndex}, nil
+}
 
-	accountIndexFloat64, ok := accountIndex.(float64)
-
-	if !ok {
-		panic(fmt.Errorf("expected byte, got: %T", e))
-	}
-	return &ProgramExecutionTemporarilyRestrictedError{
-		AccountIndex: uint8(accountIndexFloat64),
+func NewInsufficientFundsForRentError(e any) (*InsufficientFundsForRentError, error) {
+	accountIndex, err := accountIndexDetail(e)
+	if err != nil {
+		return nil, err
 	}
+	return &InsufficientFundsForRentError{AccountIndex: accountIndex}, nil
 }
 
-func MustNewInsufficientFundsForRentError(e any) *InsufficientFundsForRentError {
+// accountIndexDetail reads the `{"account_index": u8}` detail of an error.
+func accountIndexDetail(e any) (byte, error) {
 	mapE, ok := e.(map[string]interface{})
 	if !ok {
-		panic(fmt.Errorf("expected map[string]interface{}, got: %T", e))
+		return 0, fmt.Errorf("expected map[string]interface{}, got: %T", e)
 	}
 
 	accountIndex, ok := mapE["account_index"]
 	if !ok {
-		panic(fmt.Errorf("expected account_index, got: %T", e))
+		return 0, fmt.Errorf("expected account_index, got: %v", mapE)
 	}
 
-	accountIndexFloat64, ok := accountIndex.(float64)
-	if !ok {
-		panic(fmt.Errorf("expected float64, got: %T", e))
-	}
-	return &InsufficientFundsForRentError{
-		AccountIndex: uint8(accountIndexFloat64),
-	}
+	return jsonByte(accountIndex)
 }
-func MustNewDuplicateInstructionError(e any) *DuplicateInstructionError {
-	duplicateInstructionIndex, ok := e.(uint8)
-	if !ok {
-		panic(fmt.Errorf("expected byte, got: %T", e))
+
+func NewDuplicateInstructionError(e any) (*DuplicateInstructionError, error) {
+	duplicateInstructionIndex, err := jsonByte(e)
+	if err != nil {
+		return nil, err
 	}
 	return &DuplicateInstructionError{
 		duplicateInstructionIndex: duplicateInstructionIndex,
+	}, nil
+}
+
+// jsonByte reads a u8 decoded from JSON, numbers being decoded as float64.
+func jsonByte(e any) (byte, error) {
+	value, ok := e.(float64)
+	if !ok {
+		return 0, fmt.Errorf("expected float64, got: %T", e)
+	}
+	if value < 0 || value > math.MaxUint8 || value != math.Trunc(value) {
+		return 0, fmt.Errorf("expected byte, got: %v", value)
 	}
+	return byte(value), nil
 }
 
 func (e *DuplicateInstructionError) Encode(encoder *bin.Encoder) error {
@@ -312,6 +424,10 @@ const (
 	InstructionError_BuiltinProgramsMustConsumeComputeUnits
 )
 
+// InstructionError_Unknown is the reserved code of the instruction errors this version does not
+// know, its detail is an UnknownError keeping the instruction error as returned by the RPC.
+const InstructionError_Unknown InstructionErrorCode = math.MaxUint32
+
 var instructionErrorMap = map[string]InstructionErrorCode{
 	"GenericError":                           InstructionError_GenericError,
 	"InvalidArgument":                        InstructionError_InvalidArgument,
@@ -375,51 +491,111 @@ type InstructionError struct {
 	detail           errDetail
 }
 
-func MustNewInstructionError(e any) *InstructionError {
-	if e == nil {
-		return nil
+// NewInstructionError converts the `[index, error]` JSON detail of an InstructionError, an
+// error this version does not know being kept as InstructionError_Unknown.
+func NewInstructionError(e any) (*InstructionError, error) {
+	parts, ok := e.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected []any, got: %T", e)
 	}
-
-	parts := e.([]any)
 	if len(parts) != 2 {
-		panic(fmt.Errorf("invalid number of parts for InstructionError: %d", len(parts)))
+		return nil, fmt.Errorf("invalid number of parts for InstructionError: %d", len(parts))
+	}
+
+	instructionIndex, err := jsonByte(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("instruction index: %w", err)
+	}
+
+	instructionErr, err := newKnownInstructionError(instructionIndex, parts[1])
+	if err == nil {
+		return instructionErr, nil
 	}
 
-	instructionIndex := byte(parts[0].(float64))
+	unknownErr, jsonErr := newUnknownError(parts[1])
+	if jsonErr != nil {
+		return nil, fmt.Errorf("%w, keeping it as unknown: %s", err, jsonErr)
+	}
+	return &InstructionError{InstructionErrorCode: InstructionError_Unknown, InstructionIndex: instructionIndex, detail: unknownErr}, nil
+}
 
-	if errorName, isString := parts[1].(string); isString {
+func newKnownInstructionError(instructionIndex byte, e any) (*InstructionError, error) {
+	if errorName, isString := e.(string); isString {
 		if errorCode, ok := instructionErrorMap[errorName]; ok {
-			return &InstructionError{InstructionErrorCode: errorCode, InstructionIndex: instructionIndex}
+			return &InstructionError{InstructionErrorCode: errorCode, InstructionIndex: instructionIndex}, nil
 		}
-		panic(fmt.Errorf("unknown error name: %s", errorName))
+		return nil, fmt.Errorf("unknown error name: %s", errorName)
 	}
 
-	if mapErr, ok := parts[1].(map[string]any); ok {
+	if mapErr, ok := e.(map[string]any); ok {
 		if len(mapErr) != 1 {
-			panic(fmt.Errorf("unknown error map: %v", mapErr))
+			return nil, fmt.Errorf("unknown error map: %v", mapErr)
 		}
 		for errorName, details := range mapErr {
-			if errorCode, ok := instructionErrorMap[errorName]; ok {
-				var errorDetail errDetail
-
-				switch errorCode {
-				case InstructionError_Custom:
-					errorDetail = MustNewInstructionCustomError(details)
-				case InstructionError_BorshIoError:
-					errorDetail = MustNewBorshIoError(details)
-				default:
-					panic(fmt.Errorf("unknown error code: %d", errorCode))
-				}
-
-				return &InstructionError{InstructionErrorCode: errorCode, InstructionIndex: instructionIndex, detail: errorDetail}
+			errorCode, ok := instructionErrorMap[errorName]
+			if !ok {
+				return nil, fmt.Errorf("unknown error name: %s", errorName)
 			}
-			panic(fmt.Errorf("unknown error name: %s", errorName))
+
+			var errorDetail errDetail
+			var err error
+			switch errorCode {
+			case InstructionError_Custom:
+				errorDetail, err = NewInstructionCustomError(details)
+			case InstructionError_BorshIoError:
+				errorDetail, err = NewBorshIoError(details)
+			default:
+				return nil, fmt.Errorf("unexpected details for error %s", errorName)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", errorName, err)
+			}
+
+			return &InstructionError{InstructionErrorCode: errorCode, InstructionIndex: instructionIndex, detail: errorDetail}, nil
 		}
-		//we should never get here since we checked the length of the map and we are exiting only one element
 	}
 
-	panic(fmt.Errorf("unknown error type: %T", e))
+	return nil, fmt.Errorf("unknown error type: %T", e)
+}
+
+// DecodeInstructionError is the inverse of InstructionError.Encode.
+func DecodeInstructionError(decoder *bin.Decoder) (*InstructionError, error) {
+	instructionIndex, err := decoder.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode instruction index: %w", err)
+	}
+	code, err := decoder.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode error code: %w", err)
+	}
+
+	instructionErr := &InstructionError{InstructionErrorCode: InstructionErrorCode(code), InstructionIndex: instructionIndex}
+	switch instructionErr.InstructionErrorCode {
+	case InstructionError_Custom:
+		customErrorCode, err := decoder.ReadUint32(binary.LittleEndian)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode custom error code: %w", err)
+		}
+		instructionErr.detail = InstructionCustomError{CustomErrorCode: customErrorCode}
+	case InstructionError_BorshIoError:
+		msg, err := ReadString(decoder)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode borsh io error: %w", err)
+		}
+		instructionErr.detail = BorshIoError{Msg: msg}
+	case InstructionError_Unknown:
+		unknownErr, err := decodeUnknownError(decoder)
+		if err != nil {
+			return nil, err
+		}
+		instructionErr.detail = unknownErr
+	default:
+		if instructionErr.InstructionErrorCode > InstructionError_BuiltinProgramsMustConsumeComputeUnits {
+			return nil, fmt.Errorf("unknown instruction error code: %d", code)
+		}
+	}
 
+	return instructionErr, nil
 }
 
 func (i *InstructionError) Encode(encoder *bin.Encoder) error {
@@ -445,15 +621,18 @@ type InstructionCustomError struct {
 	CustomErrorCode uint32
 }
 
-func MustNewInstructionCustomError(e any) InstructionCustomError {
+func NewInstructionCustomError(e any) (InstructionCustomError, error) {
 	customErrorCode, ok := e.(float64)
 	if !ok {
-		panic(fmt.Errorf("expected float64, got: %T", e))
+		return InstructionCustomError{}, fmt.Errorf("expected float64, got: %T", e)
+	}
+	if customErrorCode < 0 || customErrorCode > math.MaxUint32 || customErrorCode != math.Trunc(customErrorCode) {
+		return InstructionCustomError{}, fmt.Errorf("expected u32, got: %v", customErrorCode)
 	}
 
 	return InstructionCustomError{
 		CustomErrorCode: uint32(customErrorCode),
-	}
+	}, nil
 }
 
 func (i InstructionCustomError) Encode(encoder *bin.Encoder) error {
@@ -468,12 +647,12 @@ type BorshIoError struct {
 	Msg string
 }
 
-func MustNewBorshIoError(a any) BorshIoError {
+func NewBorshIoError(a any) (BorshIoError, error) {
 	msg, ok := a.(string)
 	if !ok {
-		panic(fmt.Errorf("expected string, got: %T", a))
+		return BorshIoError{}, fmt.Errorf("expected string, got: %T", a)
 	}
-	return BorshIoError{Msg: msg}
+	return BorshIoError{Msg: msg}, nil
 }
 
 func (b BorshIoError) Encode(encoder *bin.Encoder) error {
@@ -496,3 +675,22 @@ func WriteString(b string, e *bin.Encoder) error {
 	}
 	return nil
 }
+
+// ReadString is the inverse of WriteString.
+func ReadString(d *bin.Decoder) (string, error) {
+	length, err := d.ReadInt64(binary.LittleEndian)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode string length: %w", err)
+	}
+	if length < 0 || length > int64(d.Remaining()) {
+		return "", fmt.Errorf("invalid string length %d, remaining [%d] bytes", length, d.Remaining())
+	}
+	out := make([]byte, length)
+	for i := range out {
+		out[i], err = d.ReadByte()
+		if err != nil {
+			return "", fmt.Errorf("unable to decode string: %w", err)
+		}
+	}
+	return string(out), nil
+}
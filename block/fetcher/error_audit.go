@@ -0,0 +1,119 @@
+package fetcher
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+	bin "github.com/streamingfast/binary"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+)
+
+type TrxErrorFindingKind string
+
+const (
+	// TrxErrorUnknownVariant is a transaction error kept with the TrxErr_Unknown code.
+	TrxErrorUnknownVariant TrxErrorFindingKind = "unknown-variant"
+	// TrxErrorUnknownInstructionVariant is an instruction error kept with the
+	// InstructionError_Unknown code.
+	TrxErrorUnknownInstructionVariant TrxErrorFindingKind = "unknown-instruction-variant"
+	// TrxErrorUndecodable is a transaction error whose bytes can't be decoded.
+	TrxErrorUndecodable TrxErrorFindingKind = "undecodable"
+	// TrxErrorUnknownValue is a value of the block the fetcher could not convert, kept in the
+	// block's unknown values.
+	TrxErrorUnknownValue TrxErrorFindingKind = "unknown-value"
+)
+
+// TrxErrorFinding is an error, or value, of a block that could not be fully converted when it
+// was fetched.
+type TrxErrorFinding struct {
+	Slot uint64
+	Kind TrxErrorFindingKind
+	// Transaction is the first signature of the transaction, empty for unknown values
+	Transaction string
+	// Variant is the name of the unknown error variant, or the path of the unknown value
+	Variant string
+	// Detail is the unknown error or value as received, or the decoding error
+	Detail string
+}
+
+func (f *TrxErrorFinding) String() string {
+	if f.Transaction == "" {
+		return fmt.Sprintf("slot %d %s %s: %s", f.Slot, f.Kind, f.Variant, f.Detail)
+	}
+	return fmt.Sprintf("slot %d %s %s (transaction %s): %s", f.Slot, f.Kind, f.Variant, f.Transaction, f.Detail)
+}
+
+// AuditTransactionErrors returns the transaction errors of block this version does not know,
+// or can't decode, as well as the block's unknown values.
+func AuditTransactionErrors(block *pbsol.Block) []*TrxErrorFinding {
+	var findings []*TrxErrorFinding
+	for _, trx := range block.Transactions {
+		if trx.Meta == nil || trx.Meta.Err == nil {
+			continue
+		}
+
+		finding := auditTransactionError(trx.Meta.Err.Err)
+		if finding == nil {
+			continue
+		}
+		finding.Slot = block.Slot
+		if trx.Transaction != nil && len(trx.Transaction.Signatures) > 0 {
+			finding.Transaction = base58.Encode(trx.Transaction.Signatures[0])
+		}
+		findings = append(findings, finding)
+	}
+
+	for _, value := range block.UnknownValues {
+		findings = append(findings, &TrxErrorFinding{
+			Slot:    block.Slot,
+			Kind:    TrxErrorUnknownValue,
+			Variant: value.Path,
+			Detail:  value.RawJson,
+		})
+	}
+	return findings
+}
+
+func auditTransactionError(data []byte) *TrxErrorFinding {
+	decoder := bin.NewDecoder(data)
+	trxErr, err := DecodeTransactionError(decoder)
+	if err == nil && decoder.Remaining() > 0 {
+		err = fmt.Errorf("%d trailing bytes", decoder.Remaining())
+	}
+	if err != nil {
+		return &TrxErrorFinding{Kind: TrxErrorUndecodable, Variant: "?", Detail: err.Error()}
+	}
+
+	if unknownErr, ok := trxErr.detail.(UnknownError); ok {
+		return &TrxErrorFinding{Kind: TrxErrorUnknownVariant, Variant: unknownVariant(unknownErr.JSON), Detail: unknownErr.JSON}
+	}
+
+	if instructionErr, ok := trxErr.detail.(*InstructionError); ok {
+		if unknownErr, ok := instructionErr.detail.(UnknownError); ok {
+			return &TrxErrorFinding{Kind: TrxErrorUnknownInstructionVariant, Variant: unknownVariant(unknownErr.JSON), Detail: unknownErr.JSON}
+		}
+	}
+	return nil
+}
+
+// unknownVariant returns the name of the Rust enum variant serialized as data, a string for
+// unit variants and a single key object otherwise.
+func unknownVariant(data string) string {
+	var value any
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		return "?"
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if len(v) == 1 {
+			for name := range v {
+				return name
+			}
+		}
+	}
+	return "?"
+}
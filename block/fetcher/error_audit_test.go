@@ -0,0 +1,54 @@
+package fetcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/mr-tron/base58"
+	bin "github.com/streamingfast/binary"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+)
+
+func encodedTransactionError(t *testing.T, errJSON string) *pbsol.TransactionError {
+	var e any
+	require.NoError(t, json.Unmarshal([]byte(errJSON), &e))
+	trxErr, err := NewTransactionError(e)
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, trxErr.Encode(bin.NewEncoder(buf)))
+	return &pbsol.TransactionError{Err: buf.Bytes()}
+}
+
+func Test_AuditTransactionErrors(t *testing.T) {
+	trx := func(signature byte, err *pbsol.TransactionError) *pbsol.ConfirmedTransaction {
+		return &pbsol.ConfirmedTransaction{
+			Transaction: &pbsol.Transaction{Signatures: [][]byte{filled(64, signature)}},
+			Meta:        &pbsol.TransactionStatusMeta{Err: err},
+		}
+	}
+
+	block := &pbsol.Block{
+		Slot: 42,
+		Transactions: []*pbsol.ConfirmedTransaction{
+			trx(1, nil),
+			trx(2, encodedTransactionError(t, `{"InstructionError": [0, {"Custom": 1}]}`)),
+			trx(3, encodedTransactionError(t, `{"NewFancyError": {"account_index": 2}}`)),
+			trx(4, encodedTransactionError(t, `{"InstructionError": [1, "NewInstructionError"]}`)),
+			trx(5, &pbsol.TransactionError{Err: []byte{0x63, 0, 0, 0}}),
+			{Transaction: &pbsol.Transaction{}, Meta: &pbsol.TransactionStatusMeta{Err: encodedTransactionError(t, `"Other"`)}},
+		},
+		UnknownValues: []*pbsol.UnknownValue{{Path: "rewards[0].reward_type", Reason: "unsupported", RawJson: `"Inflation"`}},
+	}
+
+	findings := AuditTransactionErrors(block)
+	require.Equal(t, []*TrxErrorFinding{
+		{Slot: 42, Kind: TrxErrorUnknownVariant, Transaction: base58.Encode(filled(64, 3)), Variant: "NewFancyError", Detail: `{"NewFancyError":{"account_index":2}}`},
+		{Slot: 42, Kind: TrxErrorUnknownInstructionVariant, Transaction: base58.Encode(filled(64, 4)), Variant: "NewInstructionError", Detail: `"NewInstructionError"`},
+		{Slot: 42, Kind: TrxErrorUndecodable, Transaction: base58.Encode(filled(64, 5)), Variant: "?", Detail: "unknown error code: 99"},
+		{Slot: 42, Kind: TrxErrorUnknownVariant, Variant: "Other", Detail: `"Other"`},
+		{Slot: 42, Kind: TrxErrorUnknownValue, Variant: "rewards[0].reward_type", Detail: `"Inflation"`},
+	}, findings)
+}
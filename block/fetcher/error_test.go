@@ -0,0 +1,116 @@
+package fetcher
+
+import (
+	"testing"
+
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+)
+
+func Test_TransactionErrorDecodeRoundTrip(t *testing.T) {
+	cases := []struct {
+		json     string
+		expected *pbsol.DecodedTransactionError
+	}{
+		{
+			json:     `"AccountInUse"`,
+			expected: &pbsol.DecodedTransactionError{Code: 0},
+		},
+		{
+			json:     `"UnbalancedTransaction"`,
+			expected: &pbsol.DecodedTransactionError{Code: 36},
+		},
+		{
+			json: `{"InstructionError":[2,{"Custom":6001}]}`,
+			expected: &pbsol.DecodedTransactionError{Code: pbsol.TransactionErrorCodeInstructionError, Instruction: &pbsol.DecodedInstructionError{
+				Index: 2, Code: pbsol.InstructionErrorCodeCustom, CustomCode: 6001,
+			}},
+		},
+		{
+			json: `{"InstructionError":[0,"InvalidArgument"]}`,
+			expected: &pbsol.DecodedTransactionError{Code: pbsol.TransactionErrorCodeInstructionError, Instruction: &pbsol.DecodedInstructionError{
+				Index: 0, Code: 1,
+			}},
+		},
+		{
+			json: `{"InstructionError":[1,{"BorshIoError":"Unexpected length of input <&>"}]}`,
+			expected: &pbsol.DecodedTransactionError{Code: pbsol.TransactionErrorCodeInstructionError, Instruction: &pbsol.DecodedInstructionError{
+				Index: 1, Code: pbsol.InstructionErrorCodeBorshIoError, BorshIoErrorMessage: "Unexpected length of input <&>",
+			}},
+		},
+		{
+			json:     `{"DuplicateInstruction":3}`,
+			expected: &pbsol.DecodedTransactionError{Code: pbsol.TransactionErrorCodeDuplicateInstruction, DuplicateInstructionIndex: 3},
+		},
+		{
+			json:     `{"InsufficientFundsForRent":{"account_index":2}}`,
+			expected: &pbsol.DecodedTransactionError{Code: pbsol.TransactionErrorCodeInsufficientFundsForRent, AccountIndex: 2},
+		},
+		{
+			json:     `{"ProgramExecutionTemporarilyRestricted":{"account_index":7}}`,
+			expected: &pbsol.DecodedTransactionError{Code: pbsol.TransactionErrorCodeProgramExecutionTemporarilyRestricted, AccountIndex: 7},
+		},
+		{
+			json:     `{"NewFancyError":{"a":[2],"b":1}}`,
+			expected: &pbsol.DecodedTransactionError{Code: pbsol.TransactionErrorCodeUnknown, UnknownJSON: `{"NewFancyError":{"a":[2],"b":1}}`},
+		},
+		{
+			json: `{"InstructionError":[4,{"NewFancyError":12}]}`,
+			expected: &pbsol.DecodedTransactionError{Code: pbsol.TransactionErrorCodeInstructionError, Instruction: &pbsol.DecodedInstructionError{
+				Index: 4, Code: pbsol.InstructionErrorCodeUnknown, UnknownJSON: `{"NewFancyError":12}`,
+			}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.json, func(t *testing.T) {
+			decoded, err := encodedTransactionError(t, c.json).Decode()
+			require.NoError(t, err)
+			require.Equal(t, c.expected, decoded)
+
+			rendered, err := decoded.MarshalJSON()
+			require.NoError(t, err)
+			require.Equal(t, c.json, string(rendered))
+			require.Equal(t, c.json, decoded.String())
+		})
+	}
+}
+
+func Test_TransactionErrorDecodeErrors(t *testing.T) {
+	cases := map[string][]byte{
+		"decoding error code: expected 4 bytes, got 2":                          {1, 0},
+		"unknown error code 99":                                                 {99, 0, 0, 0},
+		"1 trailing bytes after AccountInUse":                                   {0, 0, 0, 0, 1},
+		"decoding InstructionError: unknown instruction error code 200":         {8, 0, 0, 0, 1, 200, 0, 0, 0},
+		"decoding InstructionError: decoding Custom: expected 4 bytes, got 1":   {8, 0, 0, 0, 1, 25, 0, 0, 0, 1},
+		"decoding Unknown: string length 10 greater than the remaining 1 bytes": {0xff, 0xff, 0xff, 0xff, 10, 0, 0, 0, 0, 0, 0, 0, '"'},
+	}
+
+	for expected, data := range cases {
+		t.Run(expected, func(t *testing.T) {
+			_, err := (&pbsol.TransactionError{Err: data}).Decode()
+			require.Error(t, err)
+			require.Equal(t, expected, err.Error())
+		})
+	}
+}
+
+func Test_TransactionErrorCodeNames(t *testing.T) {
+	for name, code := range trxErrorMap {
+		require.Equal(t, name, pbsol.TransactionErrorCode(code).String())
+	}
+	for name, code := range instructionErrorMap {
+		require.Equal(t, name, pbsol.InstructionErrorCode(code).String())
+	}
+
+	require.Equal(t, uint32(TrxErr_InstructionError), uint32(pbsol.TransactionErrorCodeInstructionError))
+	require.Equal(t, uint32(TrxErr_DuplicateInstruction), uint32(pbsol.TransactionErrorCodeDuplicateInstruction))
+	require.Equal(t, uint32(TrxErr_InsufficientFundsForRent), uint32(pbsol.TransactionErrorCodeInsufficientFundsForRent))
+	require.Equal(t, uint32(TrxErr_ProgramExecutionTemporarilyRestricted), uint32(pbsol.TransactionErrorCodeProgramExecutionTemporarilyRestricted))
+	unknownCode := TrxErr_Unknown
+	require.Equal(t, uint32(unknownCode), uint32(pbsol.TransactionErrorCodeUnknown))
+	require.Equal(t, uint32(InstructionError_Custom), uint32(pbsol.InstructionErrorCodeCustom))
+	require.Equal(t, uint32(InstructionError_BorshIoError), uint32(pbsol.InstructionErrorCodeBorshIoError))
+	require.Equal(t, uint32(InstructionError_Unknown), uint32(pbsol.InstructionErrorCodeUnknown))
+	require.Equal(t, "TransactionErrorCode(37)", pbsol.TransactionErrorCode(37).String())
+}
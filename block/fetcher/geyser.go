@@ -0,0 +1,297 @@
+package fetcher
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/firehose-core/blockpoller"
+	"github.com/streamingfast/firehose-solana/patches"
+	pbgeyser "github.com/streamingfast/firehose-solana/pb/geyser"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GeyserFetcher serves the blocks pushed by a Yellowstone Geyser gRPC endpoint through its
+// Subscribe stream, at confirmed commitment, instead of fetching them. Streamed blocks are
+// buffered until the poller asks for them, the gap between a block and its parent slot telling
+// which slots were skipped. Slots the stream did not deliver, because they precede the
+// subscription, were missed while disconnected or were evicted from a full buffer, are fetched
+// from the fallback fetcher.
+type GeyserFetcher struct {
+	client         pbgeyser.GeyserClient
+	xToken         string
+	reconnectDelay time.Duration
+	bufferSize     int
+	fallback       blockpoller.BlockFetcher
+	patches        *patches.Registry
+	logger         *zap.Logger
+
+	lock          sync.Mutex
+	blocks        map[uint64]*pbgeyser.SubscribeUpdateBlock
+	skipped       map[uint64]bool
+	highestSlot   uint64
+	finalizedSlot uint64
+	// missedBelow is the slot under which slots neither buffered nor known as skipped will
+	// never be streamed
+	missedBelow uint64
+	// updated is closed and replaced each time a block or finalized slot is received
+	updated chan struct{}
+}
+
+// NewGeyser creates a fetcher subscribing to blocks through conn once Run is called, xToken is
+// sent as the x-token header when not empty. At most bufferSize blocks are kept until the poller
+// asks for them. fallback, which can be nil, fetches the slots the stream did not deliver,
+// without it asking for them fails. Produced blocks are corrected according to chainPatches.
+func NewGeyser(conn grpc.ClientConnInterface, xToken string, reconnectDelay time.Duration, bufferSize int, fallback blockpoller.BlockFetcher, chainPatches *patches.Registry, logger *zap.Logger) *GeyserFetcher {
+	return &GeyserFetcher{
+		client:         pbgeyser.NewGeyserClient(conn),
+		xToken:         xToken,
+		reconnectDelay: reconnectDelay,
+		bufferSize:     max(bufferSize, 1),
+		fallback:       fallback,
+		patches:        chainPatches,
+		logger:         logger,
+		blocks:         map[uint64]*pbgeyser.SubscribeUpdateBlock{},
+		skipped:        map[uint64]bool{},
+		updated:        make(chan struct{}),
+	}
+}
+
+// Run subscribes to the endpoint, subscribing again after reconnectDelay whenever the stream
+// ends, until ctx is done.
+func (f *GeyserFetcher) Run(ctx context.Context) {
+	for {
+		err := f.subscribe(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		f.logger.Warn("geyser stream ended, subscribing again", zap.Error(err), zap.Duration("subscribe_in", f.reconnectDelay))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(f.reconnectDelay):
+		}
+	}
+}
+
+func (f *GeyserFetcher) subscribe(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if f.xToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-token", f.xToken)
+	}
+
+	stream, err := f.client.Subscribe(ctx)
+	if err != nil {
+		return fmt.Errorf("opening subscribe stream: %w", err)
+	}
+
+	request := &pbgeyser.SubscribeRequest{
+		Slots:      map[string]*pbgeyser.SubscribeRequestFilterSlots{"firehose": {}},
+		Blocks:     map[string]*pbgeyser.SubscribeRequestFilterBlocks{"firehose": {IncludeTransactions: proto.Bool(true)}},
+		Commitment: pbgeyser.CommitmentLevel_CONFIRMED.Enum(),
+	}
+	if err := stream.Send(request); err != nil {
+		return fmt.Errorf("sending subscribe request: %w", err)
+	}
+
+	f.logger.Info("geyser stream subscribed")
+
+	first := true
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("receiving update: %w", err)
+		}
+
+		switch u := update.UpdateOneof.(type) {
+		case *pbgeyser.SubscribeUpdate_Block:
+			f.addBlock(u.Block, first)
+			first = false
+		case *pbgeyser.SubscribeUpdate_Slot:
+			if u.Slot.Status == pbgeyser.CommitmentLevel_FINALIZED {
+				f.setFinalized(u.Slot.Slot)
+			}
+		case *pbgeyser.SubscribeUpdate_Ping:
+			// Answering pings keeps the stream alive through load balancers
+			if err := stream.Send(&pbgeyser.SubscribeRequest{Ping: &pbgeyser.SubscribeRequestPing{Id: 1}}); err != nil {
+				return fmt.Errorf("answering ping: %w", err)
+			}
+		}
+	}
+}
+
+// addBlock buffers blk, first telling if it is the first block of a subscription, before
+// which the stream may have missed blocks.
+func (f *GeyserFetcher) addBlock(blk *pbgeyser.SubscribeUpdateBlock, first bool) {
+	slices.SortFunc(blk.Transactions, func(a, b *pbgeyser.SubscribeUpdateTransactionInfo) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.blocks[blk.Slot] = blk
+	for slot := blk.ParentSlot + 1; slot < blk.Slot; slot++ {
+		f.skipped[slot] = true
+	}
+	f.highestSlot = max(f.highestSlot, blk.Slot)
+	if first {
+		f.missedBelow = max(f.missedBelow, blk.ParentSlot+1)
+	}
+
+	for len(f.blocks) > f.bufferSize {
+		lowest := uint64(0)
+		for slot := range f.blocks {
+			if lowest == 0 || slot < lowest {
+				lowest = slot
+			}
+		}
+
+		delete(f.blocks, lowest)
+		for slot := range f.skipped {
+			if slot < lowest {
+				delete(f.skipped, slot)
+			}
+		}
+		f.missedBelow = max(f.missedBelow, lowest+1)
+	}
+
+	f.notifyLocked()
+}
+
+func (f *GeyserFetcher) setFinalized(slot uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if slot > f.finalizedSlot {
+		f.finalizedSlot = slot
+		f.notifyLocked()
+	}
+}
+
+func (f *GeyserFetcher) notifyLocked() {
+	close(f.updated)
+	f.updated = make(chan struct{})
+}
+
+func (f *GeyserFetcher) IsBlockAvailable(requestedSlot uint64) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return requestedSlot <= f.highestSlot
+}
+
+// Fetch waits for requestedSlot to be streamed, or to be known as skipped, along with a
+// finalized slot.
+func (f *GeyserFetcher) Fetch(ctx context.Context, requestedSlot uint64) (out *pbbstream.Block, skip bool, err error) {
+	if f.patches.IsSkipped(requestedSlot) {
+		f.logger.Info("fetcher block skipped by chain patches", zap.Uint64("block_num", requestedSlot))
+		return nil, true, nil
+	}
+
+	for {
+		f.lock.Lock()
+		blk, found := f.blocks[requestedSlot]
+		skipped := f.skipped[requestedSlot]
+		missed := !found && !skipped && requestedSlot < f.missedBelow
+		finalizedSlot := f.finalizedSlot
+		updated := f.updated
+		f.lock.Unlock()
+
+		switch {
+		case found && finalizedSlot != 0:
+			block, err := blockFromGeyserBlock(blk, finalizedSlot, f.patches, f.logger)
+			if err != nil {
+				return nil, false, derr.NewFatalError(fmt.Errorf("decoding block %d: %w", requestedSlot, err))
+			}
+
+			f.logger.Debug("fetcher served streamed block", zap.Uint64("block_num", requestedSlot), zap.String("block_hash", blk.Blockhash))
+			return block, false, nil
+		case skipped:
+			return nil, true, nil
+		case missed:
+			if f.fallback == nil {
+				return nil, false, derr.NewFatalError(fmt.Errorf("slot %d was not streamed by the geyser endpoint and no fallback fetcher is configured", requestedSlot))
+			}
+
+			f.logger.Info("fetching block not streamed from fallback", zap.Uint64("block_num", requestedSlot))
+			return f.fallback.Fetch(ctx, requestedSlot)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-updated:
+		}
+	}
+}
+
+func blockFromGeyserBlock(blk *pbgeyser.SubscribeUpdateBlock, finalizedSlot uint64, chainPatches *patches.Registry, logger *zap.Logger) (*pbbstream.Block, error) {
+	libNum := finalizedSlot
+	if finalizedSlot > blk.Slot {
+		libNum = blk.ParentSlot
+	}
+
+	previousBlockhash := blk.ParentBlockhash
+	if prev, ok := chainPatches.PreviousBlockhash(blk.Blockhash); ok {
+		logger.Info("patching previous block hash", zap.String("block_hash", blk.Blockhash), zap.String("previous_block_hash", prev))
+		previousBlockhash = prev
+	}
+
+	transactions := make([]*pbsol.ConfirmedTransaction, len(blk.Transactions))
+	for i, trx := range blk.Transactions {
+		transactions[i] = &pbsol.ConfirmedTransaction{
+			Transaction: trx.Transaction,
+			Meta:        trx.Meta,
+		}
+	}
+
+	// Rewards are streamed in the validator's order, RPC blocks have them sorted
+	rewards := slices.Clone(blk.Rewards.GetRewards())
+	SortRewards(rewards)
+
+	block := &pbsol.Block{
+		PreviousBlockhash: previousBlockhash,
+		Blockhash:         blk.Blockhash,
+		ParentSlot:        blk.ParentSlot,
+		Transactions:      transactions,
+		Rewards:           rewards,
+		BlockTime:         blk.BlockTime,
+		BlockHeight:       blk.BlockHeight,
+		Slot:              blk.Slot,
+	}
+
+	payload, err := anypb.New(block)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal block: %w", err)
+	}
+
+	var timestamp *timestamppb.Timestamp
+	if blk.BlockTime != nil {
+		timestamp = timestamppb.New(time.Unix(blk.BlockTime.Timestamp, 0))
+	}
+
+	return &pbbstream.Block{
+		Number:    blk.Slot,
+		Id:        blk.Blockhash,
+		ParentId:  previousBlockhash,
+		Timestamp: timestamp,
+		LibNum:    libNum,
+		ParentNum: blk.ParentSlot,
+		Payload:   payload,
+	}, nil
+}
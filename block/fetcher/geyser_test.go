@@ -0,0 +1,282 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/firehose-solana/patches"
+	pbgeyser "github.com/streamingfast/firehose-solana/pb/geyser"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// geyserServer is an in-process stand-in for a Yellowstone Geyser endpoint, it records the
+// subscribe requests then sends the updates pushed to it until disconnect is signaled.
+type geyserServer struct {
+	listener   *bufconn.Listener
+	server     *grpc.Server
+	requests   chan *pbgeyser.SubscribeRequest
+	xTokens    chan string
+	updates    chan *pbgeyser.SubscribeUpdate
+	disconnect chan struct{}
+}
+
+func newGeyserServer(t *testing.T) *geyserServer {
+	s := &geyserServer{
+		listener:   bufconn.Listen(1024 * 1024),
+		server:     grpc.NewServer(),
+		requests:   make(chan *pbgeyser.SubscribeRequest, 10),
+		xTokens:    make(chan string, 10),
+		updates:    make(chan *pbgeyser.SubscribeUpdate, 10),
+		disconnect: make(chan struct{}, 1),
+	}
+
+	pbgeyser.RegisterGeyserServer(s.server, s)
+
+	go s.server.Serve(s.listener)
+	t.Cleanup(s.server.Stop)
+	return s
+}
+
+func (s *geyserServer) Subscribe(stream pbgeyser.Geyser_SubscribeServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.xTokens <- firstOf(md.Get("x-token"))
+
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.requests <- request
+
+	for {
+		select {
+		case update := <-s.updates:
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		case <-s.disconnect:
+			return nil
+		}
+	}
+}
+
+func (s *geyserServer) dial(t *testing.T) *grpc.ClientConn {
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func (s *geyserServer) pushBlock(slot, parentSlot uint64, transactions ...*pbgeyser.SubscribeUpdateTransactionInfo) {
+	s.pushBlockWithRewards(slot, parentSlot, nil, transactions...)
+}
+
+func (s *geyserServer) pushBlockWithRewards(slot, parentSlot uint64, rewards []*pbsol.Reward, transactions ...*pbgeyser.SubscribeUpdateTransactionInfo) {
+	s.updates <- &pbgeyser.SubscribeUpdate{
+		Filters: []string{"firehose"},
+		UpdateOneof: &pbgeyser.SubscribeUpdate_Block{Block: &pbgeyser.SubscribeUpdateBlock{
+			Slot:            slot,
+			Blockhash:       blockhash(slot),
+			Rewards:         &pbsol.Rewards{Rewards: rewards},
+			BlockTime:       &pbsol.UnixTimestamp{Timestamp: 1700000000 + int64(slot)},
+			BlockHeight:     &pbsol.BlockHeight{BlockHeight: slot - 10},
+			Transactions:    transactions,
+			ParentSlot:      parentSlot,
+			ParentBlockhash: blockhash(parentSlot),
+		}},
+	}
+}
+
+func (s *geyserServer) pushFinalized(slot uint64) {
+	s.updates <- &pbgeyser.SubscribeUpdate{
+		Filters:     []string{"firehose"},
+		UpdateOneof: &pbgeyser.SubscribeUpdate_Slot{Slot: &pbgeyser.SubscribeUpdateSlot{Slot: slot, Status: pbgeyser.CommitmentLevel_FINALIZED}},
+	}
+}
+
+func blockhash(slot uint64) string {
+	return fmt.Sprintf("hash-%d", slot)
+}
+
+func firstOf(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// fallbackFetcher returns a block for every slot, recording the requested ones.
+type fallbackFetcher struct {
+	requested chan uint64
+}
+
+func (f *fallbackFetcher) IsBlockAvailable(uint64) bool {
+	return true
+}
+
+func (f *fallbackFetcher) Fetch(_ context.Context, slot uint64) (*pbbstream.Block, bool, error) {
+	f.requested <- slot
+	return &pbbstream.Block{Number: slot}, false, nil
+}
+
+func fetchWithin(t *testing.T, f *GeyserFetcher, slot uint64) (*pbbstream.Block, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	return f.Fetch(ctx, slot)
+}
+
+func Test_GeyserFetcher(t *testing.T) {
+	server := newGeyserServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chainPatches, err := patches.Parse([]byte("version: 1\nprevious_blockhashes:\n  - blockhash: hash-103\n    previous_blockhash: patched-hash\n"))
+	require.NoError(t, err)
+
+	f := NewGeyser(server.dial(t), "secret", time.Hour, 10, nil, chainPatches, zap.NewNop())
+	go f.Run(ctx)
+
+	request := <-server.requests
+	require.Equal(t, "secret", <-server.xTokens)
+	require.Equal(t, pbgeyser.CommitmentLevel_CONFIRMED, request.GetCommitment())
+	require.True(t, request.Blocks["firehose"].GetIncludeTransactions())
+	require.Contains(t, request.Slots, "firehose")
+
+	server.pushFinalized(90)
+	// Rewards in the validator's order, equal lamports keeping it
+	server.pushBlockWithRewards(100, 99, []*pbsol.Reward{
+		{Pubkey: "fee", Lamports: 2500, RewardType: pbsol.RewardType_Fee},
+		{Pubkey: "rent-b", Lamports: 1000, RewardType: pbsol.RewardType_Rent},
+		{Pubkey: "rent-a", Lamports: 1000, RewardType: pbsol.RewardType_Rent},
+	},
+		&pbgeyser.SubscribeUpdateTransactionInfo{Index: 1, Transaction: &pbsol.Transaction{Signatures: [][]byte{[]byte("second")}}, Meta: &pbsol.TransactionStatusMeta{Fee: 10}},
+		&pbgeyser.SubscribeUpdateTransactionInfo{Index: 0, Transaction: &pbsol.Transaction{Signatures: [][]byte{[]byte("first")}}, Meta: &pbsol.TransactionStatusMeta{Fee: 5}},
+	)
+	server.pushBlock(103, 100)
+
+	block, skipped, err := fetchWithin(t, f, 100)
+	require.NoError(t, err)
+	require.False(t, skipped)
+	require.Equal(t, uint64(100), block.Number)
+	require.Equal(t, "hash-100", block.Id)
+	require.Equal(t, "hash-99", block.ParentId)
+	require.Equal(t, uint64(99), block.ParentNum)
+	require.Equal(t, uint64(90), block.LibNum)
+	require.Equal(t, int64(1700000100), block.Timestamp.Seconds)
+
+	solBlock := &pbsol.Block{}
+	require.NoError(t, block.Payload.UnmarshalTo(solBlock))
+	require.Equal(t, uint64(100), solBlock.Slot)
+	require.Equal(t, uint64(90), solBlock.BlockHeight.BlockHeight)
+	require.Len(t, solBlock.Transactions, 2)
+	require.Equal(t, []byte("first"), solBlock.Transactions[0].Transaction.Signatures[0])
+	require.Equal(t, uint64(10), solBlock.Transactions[1].Meta.Fee)
+
+	var rewards []string
+	for _, reward := range solBlock.Rewards {
+		rewards = append(rewards, reward.Pubkey)
+	}
+	require.Equal(t, []string{"rent-b", "rent-a", "fee"}, rewards, "rewards are sorted like RPC blocks")
+
+	for _, slot := range []uint64{101, 102} {
+		_, skipped, err = fetchWithin(t, f, slot)
+		require.NoError(t, err)
+		require.True(t, skipped, "slot %d is between block 103 and its parent", slot)
+	}
+
+	block, _, err = fetchWithin(t, f, 103)
+	require.NoError(t, err)
+	require.Equal(t, "patched-hash", block.ParentId)
+
+	// Slot 99 precedes the subscription and there is no fallback
+	_, _, err = fetchWithin(t, f, 99)
+	var fatalErr *derr.FatalError
+	require.True(t, errors.As(err, &fatalErr), "a slot that will never be streamed should stop the poller retries")
+
+	// Slot 104 is not streamed yet, fetching it waits for it
+	done := make(chan *pbbstream.Block, 1)
+	go func() {
+		block, _, err := fetchWithin(t, f, 104)
+		require.NoError(t, err)
+		done <- block
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("fetch should wait for slot 104 to be streamed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	server.pushBlock(104, 103)
+	select {
+	case block := <-done:
+		require.Equal(t, "hash-104", block.Id)
+	case <-time.After(time.Second):
+		t.Fatal("fetch should complete once slot 104 is streamed")
+	}
+}
+
+func Test_GeyserFetcherFallback(t *testing.T) {
+	server := newGeyserServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fallback := &fallbackFetcher{requested: make(chan uint64, 10)}
+	f := NewGeyser(server.dial(t), "", 0, 2, fallback, &patches.Registry{}, zap.NewNop())
+	go f.Run(ctx)
+
+	<-server.requests
+	require.Equal(t, "", <-server.xTokens)
+
+	server.pushFinalized(40)
+	server.pushBlock(50, 49)
+
+	// Slots preceding the subscription are fetched from the fallback
+	block, _, err := fetchWithin(t, f, 45)
+	require.NoError(t, err)
+	require.Equal(t, uint64(45), block.Number)
+	require.Equal(t, uint64(45), <-fallback.requested)
+
+	// The stream resumes at slot 60 after a disconnection, slots in between were missed
+	server.disconnect <- struct{}{}
+	<-server.requests
+	server.pushBlock(60, 59)
+
+	waitFor(t, func() bool { return f.IsBlockAvailable(60) })
+	_, _, err = fetchWithin(t, f, 55)
+	require.NoError(t, err)
+	require.Equal(t, uint64(55), <-fallback.requested)
+
+	// With a buffer of 2 blocks, slot 60 is evicted once 61 and 62 are streamed
+	server.pushBlock(61, 60)
+	server.pushBlock(62, 61)
+	waitFor(t, func() bool { return f.IsBlockAvailable(62) })
+
+	_, _, err = fetchWithin(t, f, 60)
+	require.NoError(t, err)
+	require.Equal(t, uint64(60), <-fallback.requested)
+
+	block, _, err = fetchWithin(t, f, 62)
+	require.NoError(t, err)
+	require.Equal(t, "hash-62", block.Id)
+	require.Len(t, fallback.requested, 0)
+}
@@ -0,0 +1,184 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"go.uber.org/zap"
+)
+
+// WSHeadTracker follows the chain head through a websocket endpoint's slotsUpdatesSubscribe and
+// rootSubscribe notifications, the optimisticConfirmation slot updates giving the confirmed
+// slots and roots the finalized ones. The fetcher waiting for a slot uses them as is instead of
+// polling. While disconnected the tracker is not live and the fetcher polls as if there was no
+// tracker.
+type WSHeadTracker struct {
+	endpoint       string
+	reconnectDelay time.Duration
+	logger         *zap.Logger
+
+	lock      sync.Mutex
+	live      bool
+	confirmed uint64
+	finalized uint64
+	// updated is closed and replaced on each notification and when the connection is lost
+	updated chan struct{}
+}
+
+func NewWSHeadTracker(endpoint string, reconnectDelay time.Duration, logger *zap.Logger) *WSHeadTracker {
+	return &WSHeadTracker{
+		endpoint:       endpoint,
+		reconnectDelay: reconnectDelay,
+		logger:         logger,
+		updated:        make(chan struct{}),
+	}
+}
+
+// Run connects and subscribes to the endpoint, reconnecting after reconnectDelay whenever the
+// connection is lost, until ctx is done.
+func (t *WSHeadTracker) Run(ctx context.Context) {
+	for {
+		err := t.follow(ctx)
+		t.setLive(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		t.logger.Warn("websocket head tracker disconnected, polling until reconnected", zap.Error(err), zap.Duration("reconnect_in", t.reconnectDelay))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(t.reconnectDelay):
+		}
+	}
+}
+
+func (t *WSHeadTracker) follow(ctx context.Context) error {
+	client, err := ws.Connect(ctx, t.endpoint)
+	if err != nil {
+		return fmt.Errorf("connecting: %w", err)
+	}
+	defer client.Close()
+
+	slotSub, err := client.SlotsUpdatesSubscribe()
+	if err != nil {
+		return fmt.Errorf("subscribing to slot updates: %w", err)
+	}
+	defer slotSub.Unsubscribe()
+
+	rootSub, err := client.RootSubscribe()
+	if err != nil {
+		return fmt.Errorf("subscribing to roots: %w", err)
+	}
+	defer rootSub.Unsubscribe()
+
+	t.logger.Info("websocket head tracker connected")
+
+	// Recv returns a nil result and error once unsubscribed
+	errs := make(chan error, 2)
+	go func() {
+		for {
+			result, err := slotSub.Recv()
+			if err != nil {
+				errs <- fmt.Errorf("receiving slot update: %w", err)
+				return
+			}
+			if result == nil {
+				return
+			}
+			if result.Type == ws.SlotsUpdatesOptimisticConfirmation {
+				t.update(result.Slot, 0)
+			}
+		}
+	}()
+	go func() {
+		for {
+			result, err := rootSub.Recv()
+			if err != nil {
+				errs <- fmt.Errorf("receiving root: %w", err)
+				return
+			}
+			if result == nil {
+				return
+			}
+			t.update(0, uint64(*result))
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errs:
+		return err
+	}
+}
+
+func (t *WSHeadTracker) update(confirmed uint64, finalized uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.live = true
+	t.confirmed = max(t.confirmed, confirmed)
+	t.finalized = max(t.finalized, finalized)
+	t.notifyLocked()
+}
+
+func (t *WSHeadTracker) setLive(live bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.live != live {
+		t.live = live
+		t.notifyLocked()
+	}
+}
+
+func (t *WSHeadTracker) notifyLocked() {
+	close(t.updated)
+	t.updated = make(chan struct{})
+}
+
+// Head returns the latest confirmed and finalized slots pushed by the endpoint, 0 until one
+// is, live being false while disconnected.
+func (t *WSHeadTracker) Head() (confirmed uint64, finalized uint64, live bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.confirmed, t.finalized, t.live
+}
+
+// waitForSlot waits for a notification bringing the confirmed slot, or the finalized one when
+// finalized is true, to at least slot. It returns early when the tracker is not live or
+// disconnects and when timeout elapses.
+func (t *WSHeadTracker) waitForSlot(ctx context.Context, slot uint64, finalized bool, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		t.lock.Lock()
+		live, updated := t.live, t.updated
+		t.lock.Unlock()
+		if !live {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case <-updated:
+		}
+
+		confirmedSlot, finalizedSlot, _ := t.Head()
+		if finalized {
+			confirmedSlot = finalizedSlot
+		}
+		if confirmedSlot >= slot {
+			return nil
+		}
+	}
+}
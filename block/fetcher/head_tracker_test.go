@@ -0,0 +1,191 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gorilla/websocket"
+	"github.com/streamingfast/firehose-solana/patches"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testSlotSubscription = 1
+	testRootSubscription = 2
+)
+
+// wsHeadServer acknowledges slotsUpdatesSubscribe and rootSubscribe then sends the
+// notifications pushed to it, the connection is closed when disconnect is signaled.
+type wsHeadServer struct {
+	*httptest.Server
+	notifications chan string
+	disconnect    chan struct{}
+}
+
+func newWSHeadServer(t *testing.T) *wsHeadServer {
+	s := &wsHeadServer{notifications: make(chan string, 10), disconnect: make(chan struct{})}
+	upgrader := websocket.Upgrader{}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		for i := 0; i < 2; i++ {
+			var req struct {
+				ID     uint64 `json:"id"`
+				Method string `json:"method"`
+			}
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+
+			subscription := testSlotSubscription
+			if req.Method == "rootSubscribe" {
+				subscription = testRootSubscription
+			}
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","result":%d,"id":%d}`, subscription, req.ID))))
+		}
+
+		for {
+			select {
+			case notification := <-s.notifications:
+				require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(notification)))
+			case <-s.disconnect:
+				return
+			}
+		}
+	}))
+	return s
+}
+
+func (s *wsHeadServer) URL() string {
+	return "ws" + strings.TrimPrefix(s.Server.URL, "http")
+}
+
+func (s *wsHeadServer) pushSlotUpdate(slot uint64, updateType string) {
+	s.notifications <- fmt.Sprintf(`{"jsonrpc":"2.0","method":"slotsUpdatesNotification","params":{"result":{"parent":%d,"slot":%d,"timestamp":1700000000000,"type":%q},"subscription":%d}}`, slot-1, slot, updateType, testSlotSubscription)
+}
+
+func (s *wsHeadServer) pushConfirmed(slot uint64) {
+	s.pushSlotUpdate(slot, "optimisticConfirmation")
+}
+
+func (s *wsHeadServer) pushRoot(root uint64) {
+	s.notifications <- fmt.Sprintf(`{"jsonrpc":"2.0","method":"rootNotification","params":{"result":%d,"subscription":%d}}`, root, testRootSubscription)
+}
+
+func Test_WSHeadTracker(t *testing.T) {
+	server := newWSHeadServer(t)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tracker := NewWSHeadTracker(server.URL(), time.Hour, zap.NewNop())
+	go tracker.Run(ctx)
+
+	server.pushConfirmed(50)
+	server.pushRoot(10)
+	waitFor(t, func() bool {
+		confirmed, finalized, live := tracker.Head()
+		return confirmed == 50 && finalized == 10 && live
+	})
+
+	// Only optimistic confirmations move the confirmed slot, updates being received in order
+	server.pushSlotUpdate(52, "frozen")
+	server.pushConfirmed(51)
+	server.pushRoot(20)
+	waitFor(t, func() bool {
+		confirmed, finalized, _ := tracker.Head()
+		return confirmed == 51 && finalized == 20
+	})
+
+	close(server.disconnect)
+	waitFor(t, func() bool {
+		_, _, live := tracker.Head()
+		return !live
+	})
+}
+
+func Test_RPCFetcherHeadTracker(t *testing.T) {
+	counter := newGetBlockCounter()
+	rpcServer := newGetBlockServer(t, 100, counter)
+	defer rpcServer.Close()
+
+	wsServer := newWSHeadServer(t)
+	defer wsServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tracker := NewWSHeadTracker(wsServer.URL(), time.Hour, zap.NewNop())
+	go tracker.Run(ctx)
+
+	wsServer.pushConfirmed(5)
+	wsServer.pushRoot(1)
+	waitFor(t, func() bool {
+		confirmed, finalized, live := tracker.Head()
+		return confirmed == 5 && finalized == 1 && live
+	})
+
+	clients := NewRPCClients("test")
+	clients.Add("a", rpc.New(rpcServer.URL))
+	f := NewRPC(clients, clients, 0, time.Hour, 1, DefaultRetryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+	f.SetHeadTracker(tracker)
+
+	type fetched struct {
+		libNum uint64
+		err    error
+	}
+	done := make(chan fetched, 1)
+	go func() {
+		block, _, err := f.Fetch(ctx, 50)
+		if err != nil {
+			done <- fetched{err: err}
+			return
+		}
+		done <- fetched{libNum: block.LibNum}
+	}()
+
+	// Slot 50 is not confirmed yet, the fetcher waits for a notification instead of polling.
+	// Slots processed but not confirmed yet, as they are most of the time, don't wake it up.
+	select {
+	case <-done:
+		t.Fatal("fetch should wait for slot 50 to be confirmed")
+	case <-time.After(50 * time.Millisecond):
+	}
+	wsServer.pushSlotUpdate(51, "frozen")
+	wsServer.pushConfirmed(49)
+	select {
+	case <-done:
+		t.Fatal("fetch should wait for slot 50 to be confirmed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	wsServer.pushRoot(40)
+	waitFor(t, func() bool {
+		_, finalized, _ := tracker.Head()
+		return finalized == 40
+	})
+	wsServer.pushConfirmed(50)
+
+	select {
+	case result := <-done:
+		require.NoError(t, result.err)
+		require.Equal(t, uint64(40), result.libNum)
+	case <-time.After(time.Second):
+		t.Fatal("fetch should complete once slot 50 is confirmed")
+	}
+
+	counter.Lock()
+	defer counter.Unlock()
+	require.Equal(t, 0, counter.methods["getSlot"], "the confirmed and finalized slots are pushed while the tracker is live")
+}
@@ -0,0 +1,251 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/streamingfast/firehose-solana/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// Blockstore column families read from a ledger, keys are big endian slots unless noted.
+const (
+	ledgerRootColumn              = "root"
+	ledgerDataShredColumn         = "data_shred" // (slot, shred index)
+	ledgerTransactionStatusColumn = "transaction_status"
+	ledgerRewardsColumn           = "rewards"
+	ledgerBlockTimeColumn         = "blocktime"
+	ledgerBlockHeightColumn       = "block_height"
+)
+
+// LedgerDB reads the column families of a validator's blockstore, returning nil for a key
+// or column family that does not exist.
+type LedgerDB interface {
+	Get(columnFamily string, key []byte) ([]byte, error)
+	Close() error
+}
+
+// LedgerReader rebuilds rooted blocks from a ledger: transactions and blockhashes come from the
+// entries carried by the slot's data shreds, transactions status and rewards from the protobuf
+// encoded columns written by the validator.
+type LedgerReader struct {
+	db      LedgerDB
+	patches *patches.Registry
+	logger  *zap.Logger
+}
+
+func NewLedgerReader(db LedgerDB, chainPatches *patches.Registry, logger *zap.Logger) *LedgerReader {
+	return &LedgerReader{
+		db:      db,
+		patches: chainPatches,
+		logger:  logger,
+	}
+}
+
+// Read calls processBlock with each rooted block of [startSlot, stopSlot], in order.
+func (r *LedgerReader) Read(ctx context.Context, startSlot, stopSlot uint64, processBlock func(block *pbsol.Block) error) error {
+	_, err := r.ReadFrom(ctx, nil, startSlot, stopSlot, processBlock)
+	return err
+}
+
+// ReadFrom is Read continuing a previous read, possibly of another ledger, previous being the
+// last block it read. It returns the last block read, previous if none was.
+func (r *LedgerReader) ReadFrom(ctx context.Context, previous *pbsol.Block, startSlot, stopSlot uint64, processBlock func(block *pbsol.Block) error) (*pbsol.Block, error) {
+	for slot := startSlot; slot <= stopSlot; slot++ {
+		if ctx.Err() != nil {
+			return previous, ctx.Err()
+		}
+
+		blk, skipped, err := r.readBlock(slot, previous)
+		if err != nil {
+			return previous, fmt.Errorf("reading slot %d: %w", slot, err)
+		}
+		if skipped {
+			continue
+		}
+
+		if err := processBlock(blk); err != nil {
+			return previous, fmt.Errorf("processing block %d: %w", slot, err)
+		}
+		previous = blk
+	}
+	return previous, nil
+}
+
+// Block reads the block at slot, skipped being true when slot is not rooted.
+func (r *LedgerReader) Block(slot uint64) (blk *pbsol.Block, skipped bool, err error) {
+	return r.readBlock(slot, nil)
+}
+
+// readBlock reads the block at slot, previous being the last block read, if any, which saves
+// rebuilding the parent slot's entries to get the previous blockhash. When nothing was read yet
+// and the parent slot precedes the ledger, like for the first slot of a ledger started from a
+// snapshot, the previous blockhash is unknown: the ledger keeps no record of it, chain patches
+// can provide it.
+func (r *LedgerReader) readBlock(slot uint64, previous *pbsol.Block) (*pbsol.Block, bool, error) {
+	if r.patches.IsSkipped(slot) {
+		r.logger.Info("ledger slot skipped by chain patches", zap.Uint64("slot", slot))
+		return nil, true, nil
+	}
+
+	rooted, err := r.db.Get(ledgerRootColumn, ledgerSlotKey(slot))
+	if err != nil {
+		return nil, false, fmt.Errorf("reading root: %w", err)
+	}
+	if rooted == nil {
+		return nil, true, nil
+	}
+
+	entries, parentSlot, err := r.slotEntries(slot)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(entries) == 0 {
+		return nil, false, fmt.Errorf("rooted slot has no entries")
+	}
+
+	var previousBlockhash string
+	if previous != nil && previous.Slot == parentSlot {
+		previousBlockhash = previous.Blockhash
+	} else {
+		parentEntries, _, err := r.slotEntries(parentSlot)
+		if err != nil {
+			return nil, false, fmt.Errorf("reading parent slot %d: %w", parentSlot, err)
+		}
+		switch {
+		case len(parentEntries) != 0:
+			previousBlockhash = parentEntries[len(parentEntries)-1].hash
+		case previous == nil:
+			r.logger.Warn("parent slot is not in the ledger, previous blockhash is unknown", zap.Uint64("slot", slot), zap.Uint64("parent_slot", parentSlot))
+		default:
+			return nil, false, fmt.Errorf("parent slot %d has no entries", parentSlot)
+		}
+	}
+
+	blk := &pbsol.Block{
+		PreviousBlockhash: previousBlockhash,
+		Blockhash:         entries[len(entries)-1].hash,
+		ParentSlot:        parentSlot,
+		Slot:              slot,
+	}
+
+	for _, entry := range entries {
+		for _, trx := range entry.transactions {
+			meta, err := r.transactionStatus(slot, trx)
+			if err != nil {
+				return nil, false, err
+			}
+			blk.Transactions = append(blk.Transactions, &pbsol.ConfirmedTransaction{Transaction: trx, Meta: meta})
+		}
+	}
+
+	if data, err := r.db.Get(ledgerRewardsColumn, ledgerSlotKey(slot)); err != nil {
+		return nil, false, fmt.Errorf("reading rewards: %w", err)
+	} else if data != nil {
+		rewards := &pbsol.Rewards{}
+		if err := proto.Unmarshal(data, rewards); err != nil {
+			return nil, false, fmt.Errorf("decoding rewards: %w", err)
+		}
+		// Rewards are stored in the validator's order, RPC blocks have them sorted
+		blk.Rewards = rewards.Rewards
+		SortRewards(blk.Rewards)
+	}
+
+	if data, err := r.db.Get(ledgerBlockTimeColumn, ledgerSlotKey(slot)); err != nil {
+		return nil, false, fmt.Errorf("reading block time: %w", err)
+	} else if len(data) == 8 {
+		blk.BlockTime = &pbsol.UnixTimestamp{Timestamp: int64(binary.LittleEndian.Uint64(data))}
+	}
+
+	if data, err := r.db.Get(ledgerBlockHeightColumn, ledgerSlotKey(slot)); err != nil {
+		return nil, false, fmt.Errorf("reading block height: %w", err)
+	} else if len(data) == 8 {
+		blk.BlockHeight = &pbsol.BlockHeight{BlockHeight: binary.LittleEndian.Uint64(data)}
+	}
+
+	if r.patches.Apply(blk) {
+		r.logger.Warn("block patched by chain patches", zap.Uint64("slot", slot), zap.String("previous_blockhash", blk.PreviousBlockhash))
+	}
+
+	return blk, false, nil
+}
+
+// transactionStatus reads the status of trx, keyed by its first signature and slot. Ledgers
+// written before the primary index removal prefix that key with the index, 0 or 1.
+func (r *LedgerReader) transactionStatus(slot uint64, trx *pbsol.Transaction) (*pbsol.TransactionStatusMeta, error) {
+	if len(trx.Signatures) == 0 {
+		return nil, fmt.Errorf("transaction without signature")
+	}
+
+	key := append(append([]byte{}, trx.Signatures[0]...), ledgerSlotKey(slot)...)
+	for _, candidate := range [][]byte{key, append(ledgerSlotKey(0), key...), append(ledgerSlotKey(1), key...)} {
+		data, err := r.db.Get(ledgerTransactionStatusColumn, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("reading transaction status: %w", err)
+		}
+		if data == nil {
+			continue
+		}
+
+		meta := &pbsol.TransactionStatusMeta{}
+		if err := proto.Unmarshal(data, meta); err != nil {
+			return nil, fmt.Errorf("decoding status of transaction %s: %w", solana.SignatureFromBytes(trx.Signatures[0]), err)
+		}
+		for _, balance := range append(meta.PreTokenBalances, meta.PostTokenBalances...) {
+			balance.ProgramId = tokenBalanceProgramId(balance.ProgramId)
+		}
+		return meta, nil
+	}
+	return nil, fmt.Errorf("no status for transaction %s", solana.SignatureFromBytes(trx.Signatures[0]))
+}
+
+// slotEntries deshreds the data shreds of slot, from index 0 up to the one flagged as last in
+// slot, returning nil entries when the slot has no shreds.
+func (r *LedgerReader) slotEntries(slot uint64) (entries []*ledgerEntry, parentSlot uint64, err error) {
+	var batch []byte
+	for index := uint64(0); ; index++ {
+		payload, err := r.db.Get(ledgerDataShredColumn, append(ledgerSlotKey(slot), ledgerSlotKey(index)...))
+		if err != nil {
+			return nil, 0, fmt.Errorf("reading shred %d: %w", index, err)
+		}
+		if payload == nil {
+			if index == 0 {
+				return nil, 0, nil
+			}
+			return nil, 0, fmt.Errorf("shred %d is missing, slot is incomplete", index)
+		}
+
+		shred, err := parseDataShred(payload)
+		if err != nil {
+			return nil, 0, fmt.Errorf("shred %d: %w", index, err)
+		}
+		if shred.slot != slot || uint64(shred.index) != index {
+			return nil, 0, fmt.Errorf("shred %d: stored shred is for slot %d index %d", index, shred.slot, shred.index)
+		}
+		parentSlot = slot - uint64(shred.parentOffset)
+
+		batch = append(batch, shred.data...)
+		if shred.flags&shredDataComplete == 0 {
+			continue
+		}
+
+		batchEntries, err := decodeEntries(batch)
+		if err != nil {
+			return nil, 0, fmt.Errorf("decoding entries ending at shred %d: %w", index, err)
+		}
+		entries = append(entries, batchEntries...)
+		batch = nil
+
+		if shred.flags&shredLastInSlot == shredLastInSlot {
+			return entries, parentSlot, nil
+		}
+	}
+}
+
+func ledgerSlotKey(slot uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, slot)
+}
@@ -0,0 +1,13 @@
+//go:build !rocksdb
+
+package fetcher
+
+import (
+	"fmt"
+)
+
+// OpenRocksDBLedger needs RocksDB's C library, firesol must be built with the rocksdb build
+// tag, and librocksdb available, to read ledgers.
+func OpenRocksDBLedger(ledgerDir string) (LedgerDB, error) {
+	return nil, fmt.Errorf("cannot open ledger %q, firesol was built without RocksDB support, build it with -tags rocksdb", ledgerDir)
+}
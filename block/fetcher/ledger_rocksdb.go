@@ -0,0 +1,88 @@
+//go:build rocksdb
+
+package fetcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/linxGnu/grocksdb"
+)
+
+type rocksDBLedger struct {
+	db             *grocksdb.DB
+	opts           *grocksdb.Options
+	readOpts       *grocksdb.ReadOptions
+	handles        []*grocksdb.ColumnFamilyHandle
+	columnFamilies map[string]*grocksdb.ColumnFamilyHandle
+}
+
+// OpenRocksDBLedger opens the blockstore of ledgerDir, or ledgerDir itself when it has no
+// rocksdb directory, read-only: blocks written after it is opened are not seen.
+func OpenRocksDBLedger(ledgerDir string) (LedgerDB, error) {
+	path := ledgerDir
+	if info, err := os.Stat(filepath.Join(ledgerDir, "rocksdb")); err == nil && info.IsDir() {
+		path = filepath.Join(ledgerDir, "rocksdb")
+	}
+
+	opts := grocksdb.NewDefaultOptions()
+	names, err := grocksdb.ListColumnFamilies(opts, path)
+	if err != nil {
+		opts.Destroy()
+		return nil, fmt.Errorf("listing column families of %q: %w", path, err)
+	}
+
+	cfOpts := make([]*grocksdb.Options, len(names))
+	for i := range names {
+		cfOpts[i] = opts
+	}
+
+	db, handles, err := grocksdb.OpenDbForReadOnlyColumnFamilies(opts, path, names, cfOpts, false)
+	if err != nil {
+		opts.Destroy()
+		return nil, fmt.Errorf("opening ledger %q: %w", path, err)
+	}
+
+	columnFamilies := make(map[string]*grocksdb.ColumnFamilyHandle, len(names))
+	for i, name := range names {
+		columnFamilies[name] = handles[i]
+	}
+
+	return &rocksDBLedger{
+		db:             db,
+		opts:           opts,
+		readOpts:       grocksdb.NewDefaultReadOptions(),
+		handles:        handles,
+		columnFamilies: columnFamilies,
+	}, nil
+}
+
+func (l *rocksDBLedger) Get(columnFamily string, key []byte) ([]byte, error) {
+	handle, found := l.columnFamilies[columnFamily]
+	if !found {
+		return nil, nil
+	}
+
+	value, err := l.db.GetCF(l.readOpts, handle, key)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", columnFamily, err)
+	}
+	defer value.Free()
+
+	if !value.Exists() {
+		return nil, nil
+	}
+	// The slice references memory owned by RocksDB until freed
+	return append([]byte{}, value.Data()...), nil
+}
+
+func (l *rocksDBLedger) Close() error {
+	for _, handle := range l.handles {
+		handle.Destroy()
+	}
+	l.db.Close()
+	l.readOpts.Destroy()
+	l.opts.Destroy()
+	return nil
+}
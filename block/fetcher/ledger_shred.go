@@ -0,0 +1,118 @@
+package fetcher
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+)
+
+// Data shreds, legacy and merkle ones alike, start with the common header followed by the
+// data header, the data itself running up to the size recorded in the data header:
+//
+//	signature      [64]byte
+//	variant        u8
+//	slot           u64
+//	index          u32
+//	version        u16
+//	fec_set_index  u32
+//	parent_offset  u16
+//	flags          u8
+//	size           u16
+const (
+	shredVariantOffset = 64
+	shredSlotOffset    = 65
+	shredIndexOffset   = 73
+	shredParentOffset  = 83
+	shredFlagsOffset   = 85
+	shredSizeOffset    = 86
+	shredHeadersSize   = 88
+
+	shredLegacyData = 0b1010_0101
+
+	shredDataComplete = 0b0100_0000
+	shredLastInSlot   = 0b1100_0000
+)
+
+type dataShred struct {
+	slot         uint64
+	index        uint32
+	parentOffset uint16
+	flags        byte
+	data         []byte
+}
+
+func parseDataShred(payload []byte) (*dataShred, error) {
+	if len(payload) < shredHeadersSize {
+		return nil, fmt.Errorf("shred of %d bytes is shorter than its headers", len(payload))
+	}
+
+	switch variant := payload[shredVariantOffset]; {
+	case variant == shredLegacyData:
+	case variant&0xf0 == 0x80, variant&0xf0 == 0x90, variant&0xf0 == 0xb0:
+		// Merkle data shreds, the low nibble being the proof size
+	default:
+		return nil, fmt.Errorf("unsupported shred variant %#x", variant)
+	}
+
+	size := int(binary.LittleEndian.Uint16(payload[shredSizeOffset:]))
+	if size < shredHeadersSize || size > len(payload) {
+		return nil, fmt.Errorf("invalid shred data size %d for a %d bytes shred", size, len(payload))
+	}
+
+	return &dataShred{
+		slot:         binary.LittleEndian.Uint64(payload[shredSlotOffset:]),
+		index:        binary.LittleEndian.Uint32(payload[shredIndexOffset:]),
+		parentOffset: binary.LittleEndian.Uint16(payload[shredParentOffset:]),
+		flags:        payload[shredFlagsOffset],
+		data:         payload[shredHeadersSize:size],
+	}, nil
+}
+
+type ledgerEntry struct {
+	hash         string
+	transactions []*pbsol.Transaction
+}
+
+// decodeEntries decodes the bincode `Vec<Entry>` carried by a batch of data shreds:
+//
+//	struct Entry {
+//	    num_hashes: u64,
+//	    hash: Hash,
+//	    transactions: Vec<VersionedTransaction>,
+//	}
+func decodeEntries(data []byte) ([]*ledgerEntry, error) {
+	d := &bincodeDecoder{data: data}
+
+	count, err := d.readLength()
+	if err != nil {
+		return nil, fmt.Errorf("reading entries length: %w", err)
+	}
+
+	entries := make([]*ledgerEntry, 0, count)
+	for i := uint64(0); i < count; i++ {
+		if _, err := d.readUint64(); err != nil {
+			return nil, fmt.Errorf("reading entry %d num hashes: %w", i, err)
+		}
+		hash, err := d.readBytes(32)
+		if err != nil {
+			return nil, fmt.Errorf("reading entry %d hash: %w", i, err)
+		}
+
+		trxCount, err := d.readLength()
+		if err != nil {
+			return nil, fmt.Errorf("reading entry %d transactions length: %w", i, err)
+		}
+		entry := &ledgerEntry{hash: solana.HashFromBytes(hash).String()}
+		for j := uint64(0); j < trxCount; j++ {
+			trx, err := d.readTransaction()
+			if err != nil {
+				return nil, fmt.Errorf("reading entry %d transaction %d: %w", i, j, err)
+			}
+			entry.transactions = append(entry.transactions, trx)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,331 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/streamingfast/firehose-solana/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type memLedger map[string]map[string][]byte
+
+func (l memLedger) Get(columnFamily string, key []byte) ([]byte, error) {
+	return l[columnFamily][string(key)], nil
+}
+
+func (l memLedger) Close() error {
+	return nil
+}
+
+func (l memLedger) put(columnFamily string, key []byte, value []byte) {
+	if l[columnFamily] == nil {
+		l[columnFamily] = map[string][]byte{}
+	}
+	l[columnFamily][string(key)] = value
+}
+
+// putSlot stores the entries of slot as legacy data shreds of at most shredDataSize bytes, each
+// batch of entries ending with a data complete shred.
+func (l memLedger) putSlot(slot, parentSlot uint64, shredDataSize int, batches ...[]byte) {
+	index := uint32(0)
+	for i, batch := range batches {
+		for offset := 0; offset < len(batch); offset += shredDataSize {
+			data := batch[offset:min(offset+shredDataSize, len(batch))]
+
+			var flags byte
+			if offset+shredDataSize >= len(batch) {
+				flags = shredDataComplete
+				if i == len(batches)-1 {
+					flags = shredLastInSlot
+				}
+			}
+
+			payload := make([]byte, shredHeadersSize, 1228)
+			payload[shredVariantOffset] = shredLegacyData
+			binary.LittleEndian.PutUint64(payload[shredSlotOffset:], slot)
+			binary.LittleEndian.PutUint32(payload[shredIndexOffset:], index)
+			binary.LittleEndian.PutUint16(payload[shredParentOffset:], uint16(slot-parentSlot))
+			payload[shredFlagsOffset] = flags
+			binary.LittleEndian.PutUint16(payload[shredSizeOffset:], uint16(shredHeadersSize+len(data)))
+			payload = append(payload, data...)
+			// Padding past the data size is not part of the entries
+			payload = append(payload, filled(cap(payload)-len(payload), 0xff)...)
+
+			l.put(ledgerDataShredColumn, append(ledgerSlotKey(slot), ledgerSlotKey(uint64(index))...), payload)
+			index++
+		}
+	}
+}
+
+type testEntry struct {
+	hash         byte
+	transactions []byte // first signature byte of each transaction
+}
+
+func encodeEntries(entries ...testEntry) []byte {
+	w := &bincodeWriter{}
+	w.u64(uint64(len(entries)))
+	for _, entry := range entries {
+		w.u64(12500)
+		w.Write(filled(32, entry.hash))
+		w.u64(uint64(len(entry.transactions)))
+		for _, signature := range entry.transactions {
+			w.WriteByte(1)
+			w.Write(filled(64, signature))
+			w.Write([]byte{1, 0, 1}) // header
+			w.WriteByte(1)
+			w.Write(filled(32, signature))
+			w.Write(filled(32, 9)) // recent blockhash
+			w.WriteByte(0)         // instructions
+		}
+	}
+	return w.Bytes()
+}
+
+func (l memLedger) putStatus(slot uint64, signature byte, fee uint64, primaryIndex *uint64) {
+	key := append(filled(64, signature), ledgerSlotKey(slot)...)
+	if primaryIndex != nil {
+		key = append(ledgerSlotKey(*primaryIndex), key...)
+	}
+
+	data, err := proto.Marshal(&pbsol.TransactionStatusMeta{Fee: fee, LogMessages: []string{"log"}})
+	if err != nil {
+		panic(err)
+	}
+	l.put(ledgerTransactionStatusColumn, key, data)
+}
+
+func hashString(b byte) string {
+	return solana.HashFromBytes(filled(32, b)).String()
+}
+
+func newTestLedger(t *testing.T) memLedger {
+	l := memLedger{}
+
+	l.put(ledgerRootColumn, ledgerSlotKey(9), []byte{1})
+	l.putSlot(9, 8, 1000, encodeEntries(testEntry{hash: 0x09}))
+
+	l.put(ledgerRootColumn, ledgerSlotKey(10), []byte{1})
+	l.putSlot(10, 9, 1000, encodeEntries(testEntry{hash: 0x0a, transactions: []byte{0xa1}}))
+	l.putStatus(10, 0xa1, 5000, nil)
+
+	// Slot 11 has shreds but was not rooted, a fork that got abandoned
+	l.putSlot(11, 10, 1000, encodeEntries(testEntry{hash: 0x0b}))
+
+	// Slot 12 has two batches spread across several shreds each
+	l.put(ledgerRootColumn, ledgerSlotKey(12), []byte{1})
+	l.putSlot(12, 10, 100,
+		encodeEntries(testEntry{hash: 0xc1, transactions: []byte{0xc1, 0xc2}}, testEntry{hash: 0xc2}),
+		encodeEntries(testEntry{hash: 0xc3, transactions: []byte{0xc3}}),
+	)
+	legacyPrimaryIndex := uint64(1)
+	l.putStatus(12, 0xc1, 1, nil)
+	l.putStatus(12, 0xc2, 2, &legacyPrimaryIndex)
+	l.putStatus(12, 0xc3, 3, nil)
+
+	rewards, err := proto.Marshal(&pbsol.Rewards{Rewards: []*pbsol.Reward{{Pubkey: "validator", Lamports: 10, RewardType: pbsol.RewardType_Fee}}})
+	require.NoError(t, err)
+	l.put(ledgerRewardsColumn, ledgerSlotKey(12), rewards)
+	l.put(ledgerBlockTimeColumn, ledgerSlotKey(12), binary.LittleEndian.AppendUint64(nil, 1700000000))
+	l.put(ledgerBlockHeightColumn, ledgerSlotKey(12), binary.LittleEndian.AppendUint64(nil, 8))
+
+	return l
+}
+
+func Test_LedgerReaderBlock(t *testing.T) {
+	reader := NewLedgerReader(newTestLedger(t), &patches.Registry{}, zap.NewNop())
+
+	blk, skipped, err := reader.Block(12)
+	require.NoError(t, err)
+	require.False(t, skipped)
+
+	require.Equal(t, uint64(12), blk.Slot)
+	require.Equal(t, uint64(10), blk.ParentSlot)
+	require.Equal(t, hashString(0xc3), blk.Blockhash, "blockhash is the hash of the slot's last entry")
+	require.Equal(t, hashString(0x0a), blk.PreviousBlockhash)
+	require.Equal(t, int64(1700000000), blk.BlockTime.Timestamp)
+	require.Equal(t, uint64(8), blk.BlockHeight.BlockHeight)
+	require.Len(t, blk.Rewards, 1)
+	require.Equal(t, "validator", blk.Rewards[0].Pubkey)
+
+	require.Len(t, blk.Transactions, 3)
+	for i, signature := range []byte{0xc1, 0xc2, 0xc3} {
+		trx := blk.Transactions[i]
+		require.Equal(t, filled(64, signature), trx.Transaction.Signatures[0])
+		require.Equal(t, filled(32, 9), trx.Transaction.Message.RecentBlockhash)
+		require.Equal(t, uint64(i+1), trx.Meta.Fee)
+		require.Equal(t, []string{"log"}, trx.Meta.LogMessages)
+	}
+
+	_, skipped, err = reader.Block(11)
+	require.NoError(t, err)
+	require.True(t, skipped, "slot 11 is not rooted")
+
+	_, skipped, err = reader.Block(13)
+	require.NoError(t, err)
+	require.True(t, skipped)
+}
+
+func Test_LedgerReaderRead(t *testing.T) {
+	chainPatches, err := patches.Parse([]byte("version: 1\nprevious_blockhashes:\n  - blockhash: " + hashString(0x0a) + "\n    previous_blockhash: patched\n"))
+	require.NoError(t, err)
+	reader := NewLedgerReader(newTestLedger(t), chainPatches, zap.NewNop())
+
+	var blocks []*pbsol.Block
+	err = reader.Read(context.Background(), 10, 13, func(block *pbsol.Block) error {
+		blocks = append(blocks, block)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, blocks, 2)
+	require.Equal(t, []uint64{10, 12}, []uint64{blocks[0].Slot, blocks[1].Slot})
+	require.Equal(t, "patched", blocks[0].PreviousBlockhash)
+	require.Equal(t, blocks[0].Blockhash, blocks[1].PreviousBlockhash)
+}
+
+func Test_LedgerReaderFirstSlot(t *testing.T) {
+	reader := NewLedgerReader(newTestLedger(t), &patches.Registry{}, zap.NewNop())
+
+	var blocks []*pbsol.Block
+	err := reader.Read(context.Background(), 9, 12, func(block *pbsol.Block) error {
+		blocks = append(blocks, block)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, blocks, 3)
+	require.Equal(t, []uint64{9, 10, 12}, []uint64{blocks[0].Slot, blocks[1].Slot, blocks[2].Slot})
+	require.Equal(t, uint64(8), blocks[0].ParentSlot)
+	require.Equal(t, "", blocks[0].PreviousBlockhash, "slot 9's parent precedes the ledger")
+	require.Equal(t, hashString(0x09), blocks[0].Blockhash)
+	require.Equal(t, blocks[0].Blockhash, blocks[1].PreviousBlockhash)
+
+	chainPatches, err := patches.Parse([]byte("version: 1\nprevious_blockhashes:\n  - blockhash: " + hashString(0x09) + "\n    previous_blockhash: from-patches\n"))
+	require.NoError(t, err)
+	blk, _, err := NewLedgerReader(newTestLedger(t), chainPatches, zap.NewNop()).Block(9)
+	require.NoError(t, err)
+	require.Equal(t, "from-patches", blk.PreviousBlockhash)
+}
+
+func Test_LedgerReaderErrors(t *testing.T) {
+	t.Run("parent missing after the first block", func(t *testing.T) {
+		previous := &pbsol.Block{Slot: 7, Blockhash: "previous"}
+		_, err := NewLedgerReader(newTestLedger(t), &patches.Registry{}, zap.NewNop()).ReadFrom(context.Background(), previous, 9, 9, func(*pbsol.Block) error { return nil })
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parent slot 8 has no entries")
+	})
+
+	t.Run("missing transaction status", func(t *testing.T) {
+		ledger := newTestLedger(t)
+		delete(ledger[ledgerTransactionStatusColumn], string(append(filled(64, 0xa1), ledgerSlotKey(10)...)))
+
+		_, _, err := NewLedgerReader(ledger, &patches.Registry{}, zap.NewNop()).Block(10)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no status for transaction")
+	})
+
+	t.Run("incomplete slot", func(t *testing.T) {
+		ledger := newTestLedger(t)
+		delete(ledger[ledgerDataShredColumn], string(append(ledgerSlotKey(12), ledgerSlotKey(1)...)))
+
+		_, _, err := NewLedgerReader(ledger, &patches.Registry{}, zap.NewNop()).Block(12)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "shred 1 is missing")
+	})
+}
+
+// Test_LedgerReaderMatchesRPC reads a block from the ledger and converts the same block as
+// getBlock returns it, both having to be equal field by field.
+func Test_LedgerReaderMatchesRPC(t *testing.T) {
+	ledger := memLedger{}
+	ledger.put(ledgerRootColumn, ledgerSlotKey(20), []byte{1})
+	ledger.putSlot(20, 19, 1000, encodeEntries(testEntry{hash: 0x14}))
+	ledger.put(ledgerRootColumn, ledgerSlotKey(21), []byte{1})
+	ledger.putSlot(21, 20, 1000, encodeEntries(testEntry{hash: 0x15, transactions: []byte{0xd1}}))
+
+	mint := solana.PublicKeyFromBytes(filled(32, 0x0d)).String()
+	status, err := proto.Marshal(&pbsol.TransactionStatusMeta{
+		Fee:          5000,
+		PreBalances:  []uint64{100000},
+		PostBalances: []uint64{95000},
+		LogMessages:  []string{"Program log: transfer"},
+		// The validator records the transaction returned no data
+		ReturnDataNone: true,
+		PreTokenBalances: []*pbsol.TokenBalance{
+			{AccountIndex: 0, Mint: mint, UiTokenAmount: &pbsol.UiTokenAmount{UiAmount: 1, Decimals: 2, Amount: "100", UiAmountString: "1"}},
+		},
+		// An unknown program id, empty in RPC blocks
+		PostTokenBalances: []*pbsol.TokenBalance{
+			{AccountIndex: 0, Mint: mint, UiTokenAmount: &pbsol.UiTokenAmount{Decimals: 2, Amount: "0", UiAmountString: "0"}, ProgramId: "11111111111111111111111111111111"},
+		},
+	})
+	require.NoError(t, err)
+	ledger.put(ledgerTransactionStatusColumn, append(filled(64, 0xd1), ledgerSlotKey(21)...), status)
+
+	// Rewards as the validator stores them, not by lamports
+	rewards, err := proto.Marshal(&pbsol.Rewards{Rewards: []*pbsol.Reward{
+		{Pubkey: "11111111111111111111111111111111", Lamports: 2500, PostBalance: 12500, RewardType: pbsol.RewardType_Fee},
+		{Pubkey: "SysvarC1ock11111111111111111111111111111111", Lamports: 1000, PostBalance: 11000, RewardType: pbsol.RewardType_Rent},
+	}})
+	require.NoError(t, err)
+	ledger.put(ledgerRewardsColumn, ledgerSlotKey(21), rewards)
+	ledger.put(ledgerBlockTimeColumn, ledgerSlotKey(21), binary.LittleEndian.AppendUint64(nil, 1700000021))
+	ledger.put(ledgerBlockHeightColumn, ledgerSlotKey(21), binary.LittleEndian.AppendUint64(nil, 11))
+
+	ledgerBlock, _, err := NewLedgerReader(ledger, &patches.Registry{}, zap.NewNop()).Block(21)
+	require.NoError(t, err)
+
+	trx := &solana.Transaction{
+		Signatures: []solana.Signature{solana.SignatureFromBytes(filled(64, 0xd1))},
+		Message: solana.Message{
+			Header:          solana.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+			AccountKeys:     []solana.PublicKey{solana.PublicKeyFromBytes(filled(32, 0xd1))},
+			RecentBlockhash: solana.HashFromBytes(filled(32, 9)),
+		},
+	}
+	trxData, err := trx.MarshalBinary()
+	require.NoError(t, err)
+
+	result := &rpc.GetBlockResult{}
+	err = json.Unmarshal([]byte(fmt.Sprintf(`{
+		"blockhash": %q,
+		"previousBlockhash": %q,
+		"parentSlot": 20,
+		"blockTime": 1700000021,
+		"blockHeight": 11,
+		"transactions": [{
+			"transaction": [%q, "base64"],
+			"meta": {
+				"err": null, "fee": 5000, "preBalances": [100000], "postBalances": [95000],
+				"innerInstructions": [], "logMessages": ["Program log: transfer"],
+				"preTokenBalances": [{"accountIndex": 0, "mint": %q, "uiTokenAmount": {"uiAmount": 1, "decimals": 2, "amount": "100", "uiAmountString": "1"}}],
+				"postTokenBalances": [{"accountIndex": 0, "mint": %q, "uiTokenAmount": {"uiAmount": null, "decimals": 2, "amount": "0", "uiAmountString": "0"}}],
+				"rewards": [], "loadedAddresses": {"writable": [], "readonly": []}
+			}
+		}],
+		"rewards": [
+			{"pubkey": "11111111111111111111111111111111", "lamports": 2500, "postBalance": 12500, "rewardType": "Fee"},
+			{"pubkey": "SysvarC1ock11111111111111111111111111111111", "lamports": 1000, "postBalance": 11000, "rewardType": "Rent"}
+		]
+	}`, hashString(0x15), hashString(0x14), base64.StdEncoding.EncodeToString(trxData), mint, mint)), result)
+	require.NoError(t, err)
+
+	block, err := blockFromBlockResult(21, 21, result, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+	require.NoError(t, err)
+	rpcBlock := &pbsol.Block{}
+	require.NoError(t, block.Payload.UnmarshalTo(rpcBlock))
+
+	require.Empty(t, DiffMessages(rpcBlock, ledgerBlock))
+	require.Equal(t, int64(1000), ledgerBlock.Rewards[0].Lamports, "rewards are sorted like RPC blocks")
+}
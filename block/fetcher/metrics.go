@@ -0,0 +1,16 @@
+package fetcher
+
+import "github.com/streamingfast/dmetrics"
+
+func RegisterMetrics() {
+	metrics.Register()
+}
+
+var metrics = dmetrics.NewSet()
+
+var RPCEndpointRequestCount = metrics.NewCounterVec("firesol_rpc_endpoint_request_count", []string{"pool", "endpoint", "status"}, "Number of RPC requests made to an endpoint of a pool, by status")
+var RPCEndpointLatency = metrics.NewGaugeVec("firesol_rpc_endpoint_latency_ms", []string{"pool", "endpoint"}, "Moving average of an endpoint's RPC request latency in milliseconds")
+var RPCEndpointErrorRate = metrics.NewGaugeVec("firesol_rpc_endpoint_error_rate", []string{"pool", "endpoint"}, "Moving average of an endpoint's failed RPC requests ratio")
+var RPCEndpointSlotLag = metrics.NewGaugeVec("firesol_rpc_endpoint_slot_lag", []string{"pool", "endpoint"}, "Number of slots an endpoint's confirmed slot is behind the most advanced endpoint")
+var RPCEndpointBenched = metrics.NewGaugeVec("firesol_rpc_endpoint_benched", []string{"pool", "endpoint"}, "1 when an endpoint is temporarily benched after consecutive failures")
+var RPCForkedBlockCount = metrics.NewCounterVec("firesol_rpc_forked_block_count", []string{"status"}, "Number of blocks emitted before being finalized found not to be part of the finalized chain, by status (orphaned or replaced)")
@@ -0,0 +1,21 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/streamingfast/firehose-core/blockpoller"
+)
+
+// RunPoller runs poller from startBlock until it stops. The poller shuts itself down when
+// fetching a block fails for good, Run returning nil in that case, the error it was shut down
+// with is returned then.
+func RunPoller(ctx context.Context, poller *blockpoller.BlockPoller, startBlock uint64, blockFetchBatchSize int) error {
+	if err := poller.Run(ctx, startBlock, blockFetchBatchSize); err != nil {
+		return fmt.Errorf("running poller: %w", err)
+	}
+	if err := poller.Err(); err != nil {
+		return fmt.Errorf("running poller: %w", err)
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gagliardetto/solana-go"
@@ -15,7 +17,8 @@ import (
 	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
 	bin "github.com/streamingfast/binary"
 	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
-	firecoreRPC "github.com/streamingfast/firehose-core/rpc"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/firehose-solana/patches"
 	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
 	sfsol "github.com/streamingfast/solana-go"
 	"go.uber.org/zap"
@@ -24,83 +27,190 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-//todo: find right value for maxSupportedTransactionVersion
+// RequestConfig configures the getBlock calls of an RPCFetcher.
+type RequestConfig struct {
+	// Commitment is the commitment blocks are fetched at, either rpc.CommitmentConfirmed or
+	// rpc.CommitmentFinalized, the latter only fetching slots once they are finalized.
+	Commitment rpc.CommitmentType
+	// MaxSupportedTransactionVersion is the highest transaction version requested, blocks
+	// holding a transaction of a higher version are rejected.
+	MaxSupportedTransactionVersion uint64
+}
 
-var MaxSupportedTransactionVersion = uint64(0)
-var GetBlockOpts = &rpc.GetBlockOpts{
+var DefaultRequestConfig = RequestConfig{
 	Commitment:                     rpc.CommitmentConfirmed,
-	MaxSupportedTransactionVersion: &MaxSupportedTransactionVersion,
+	MaxSupportedTransactionVersion: 0,
+}
+
+func (c RequestConfig) Validate() error {
+	switch c.Commitment {
+	case rpc.CommitmentConfirmed, rpc.CommitmentFinalized:
+		return nil
+	}
+	return fmt.Errorf("unsupported commitment %q, must be %q or %q", c.Commitment, rpc.CommitmentConfirmed, rpc.CommitmentFinalized)
+}
+
+func (c RequestConfig) finalizedOnly() bool {
+	return c.Commitment == rpc.CommitmentFinalized
+}
+
+func (c RequestConfig) getBlockOpts() *rpc.GetBlockOpts {
+	maxSupportedTransactionVersion := c.MaxSupportedTransactionVersion
+	return &rpc.GetBlockOpts{
+		Commitment:                     c.Commitment,
+		MaxSupportedTransactionVersion: &maxSupportedTransactionVersion,
+	}
+}
+
+// checkTransactionVersions returns an *UnsupportedTransactionVersionError if a transaction of
+// result is of a version above the maximum supported one, which an endpoint should have refused.
+func (c RequestConfig) checkTransactionVersions(slot uint64, result *rpc.GetBlockResult) error {
+	for i, trx := range result.Transactions {
+		if trx.Version != rpc.LegacyTransactionVersion && (trx.Version < 0 || uint64(trx.Version) > c.MaxSupportedTransactionVersion) {
+			return &UnsupportedTransactionVersionError{
+				Slot:                           slot,
+				MaxSupportedTransactionVersion: c.MaxSupportedTransactionVersion,
+				Detail:                         fmt.Sprintf("transaction %d is of version %d", i, trx.Version),
+			}
+		}
+	}
+	return nil
+}
+
+// UnsupportedTransactionVersionError is returned for a block holding a transaction of a version
+// above the maximum supported one, retrying won't fetch it without raising that maximum.
+type UnsupportedTransactionVersionError struct {
+	Slot                           uint64
+	MaxSupportedTransactionVersion uint64
+	// Detail is the offending transaction or the endpoint's rejection
+	Detail string
+}
+
+func (e *UnsupportedTransactionVersionError) Error() string {
+	return fmt.Sprintf("slot %d holds a transaction of a version above the maximum supported transaction version %d: %s", e.Slot, e.MaxSupportedTransactionVersion, e.Detail)
 }
 
 type fetchBlock func(ctx context.Context, requestedSlot uint64) (slot uint64, out *rpc.GetBlockResult, err error)
 
 type RPCFetcher struct {
-	rpcClients               *firecoreRPC.Clients[*rpc.Client]
+	// slotClients track the chain tip while blockClients serve getBlock calls, each with its
+	// own fallbacks, they can be the same endpoints
+	slotClients              *RPCClients
+	blockClients             *RPCClients
+	retryConfig              RetryConfig
+	requestConfig            RequestConfig
+	conversionPolicy         ConversionPolicy
+	patches                  *patches.Registry
 	latestConfirmedSlot      uint64
 	latestFinalizedSlot      uint64
 	latestBlockRetryInterval time.Duration
 	fetchInterval            time.Duration
 	lastFetchAt              time.Time
 	logger                   *zap.Logger
+
+	// headLock guards the latest confirmed and finalized slots, the poller calls Fetch concurrently
+	headLock sync.Mutex
+
+	// headTracker, when set, pushes head updates instead of polling for them
+	headTracker *WSHeadTracker
+
+	prefetchWindow int
+	prefetchLock   sync.Mutex
+	prefetched     map[uint64]*prefetchedBlock
+
+	// forks tracks the blocks emitted before being finalized
+	forks *forkTracker
+
+	// ctx is canceled by Close, ending the prefetches still running
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// prefetchedBlock is a block fetch started ahead of the poller asking for it, done is
+// closed once the other fields are set.
+type prefetchedBlock struct {
+	done   chan struct{}
+	result *rpc.GetBlockResult
+	skip   bool
+	err    error
 }
 
-func NewRPC(rpcClients *firecoreRPC.Clients[*rpc.Client], fetchInterval time.Duration, latestBlockRetryInterval time.Duration, logger *zap.Logger) *RPCFetcher {
+// NewRPC creates a fetcher tracking the latest slots through slotClients and fetching blocks
+// through blockClients. When asked for a slot, it also starts fetching up to prefetchWindow-1
+// following confirmed slots concurrently, spreading them across blockClients. A prefetchWindow
+// of 1 or less only fetches the requested slot. Failing getBlock calls are retried according
+// to retryConfig and made according to requestConfig, slots being waited for until they reach
+// its commitment. Values of the blocks that can't be converted are handled according to
+// conversionPolicy. Produced blocks are corrected according to chainPatches. Blocks emitted
+// before being finalized are checked against the finalized chain once their slot is finalized,
+// the ones not part of it being fetched again, or reported as skipped, when the poller asks
+// for them again while following the finalized fork.
+func NewRPC(slotClients *RPCClients, blockClients *RPCClients, fetchInterval time.Duration, latestBlockRetryInterval time.Duration, prefetchWindow int, retryConfig RetryConfig, requestConfig RequestConfig, conversionPolicy ConversionPolicy, chainPatches *patches.Registry, logger *zap.Logger) *RPCFetcher {
 	f := &RPCFetcher{
-		rpcClients:               rpcClients,
+		slotClients:              slotClients,
+		blockClients:             blockClients,
+		retryConfig:              retryConfig,
+		requestConfig:            requestConfig,
+		conversionPolicy:         conversionPolicy,
+		patches:                  chainPatches,
 		fetchInterval:            fetchInterval,
 		latestBlockRetryInterval: latestBlockRetryInterval,
+		prefetchWindow:           max(prefetchWindow, 1),
+		prefetched:               map[uint64]*prefetchedBlock{},
+		forks:                    newForkTracker(),
 		logger:                   logger,
 	}
+	f.ctx, f.cancel = context.WithCancel(context.Background())
 	return f
 }
 
+// Close cancels the prefetches still running, the fetcher must not be used afterward.
+func (f *RPCFetcher) Close() {
+	f.cancel()
+}
+
+// SetHeadTracker makes the fetcher wait for slots using tracker's notifications, it must be
+// called before the fetcher is used and tracker must be running.
+func (f *RPCFetcher) SetHeadTracker(tracker *WSHeadTracker) {
+	f.headTracker = tracker
+}
+
 func (f *RPCFetcher) IsBlockAvailable(requestedSlot uint64) bool {
-	f.logger.Info("checking if block is available", zap.Uint64("request_block_num", requestedSlot), zap.Uint64("latest_confirmed_slot", f.latestConfirmedSlot))
-	return requestedSlot <= f.latestConfirmedSlot
+	latestConfirmedSlot, _ := f.head()
+
+	f.logger.Info("checking if block is available", zap.Uint64("request_block_num", requestedSlot), zap.Uint64("latest_confirmed_slot", latestConfirmedSlot))
+	return requestedSlot <= latestConfirmedSlot
 }
 
 func (f *RPCFetcher) Fetch(ctx context.Context, requestedSlot uint64) (out *pbbstream.Block, skip bool, err error) {
-	//THIS IS A FKG Ugly hack!
-	if requestedSlot >= 13334464 && requestedSlot <= 13334475 {
+	if f.patches.IsSkipped(requestedSlot) {
+		f.logger.Info("fetcher block skipped by chain patches", zap.Uint64("block_num", requestedSlot))
 		return nil, true, nil
 	}
 
-	sleepDuration := time.Duration(0)
-	_, err = firecoreRPC.WithClients(f.rpcClients, func(client *rpc.Client) (na interface{}, err error) {
-
-		for f.latestConfirmedSlot < requestedSlot {
-			time.Sleep(sleepDuration)
-			f.latestConfirmedSlot, err = client.GetSlot(ctx, rpc.CommitmentConfirmed)
-			if err != nil {
-				return nil, fmt.Errorf("fetching latestConfirmedSlot block num: %w", err)
-			}
-
-			f.logger.Info("got latest confirmed slot block", zap.Uint64("latest_confirmed_slot", f.latestConfirmedSlot), zap.Uint64("requested_block_num", requestedSlot))
-			//
-			if f.latestConfirmedSlot >= requestedSlot {
-				break
-			}
-			sleepDuration = f.latestBlockRetryInterval
-		}
-
-		if f.latestFinalizedSlot < requestedSlot {
-			f.latestFinalizedSlot, err = client.GetSlot(ctx, rpc.CommitmentFinalized)
-			if err != nil {
-				return nil, fmt.Errorf("fetching latest finalized Slot block num: %w", err)
-			}
-			f.logger.Info("got latest finalized slot block", zap.Uint64("latest_finalized_slot", f.latestFinalizedSlot), zap.Uint64("requested_block_num", requestedSlot))
-		}
-
-		return nil, nil
-	})
-
+	latestConfirmedSlot, latestFinalizedSlot, err := f.waitForSlot(ctx, requestedSlot)
 	if err != nil {
 		return nil, false, err
 	}
 
-	f.logger.Info("fetcher fetching block", zap.Uint64("block_num", requestedSlot), zap.Uint64("latest_finalized_slot", f.latestFinalizedSlot), zap.Uint64("latest_confirmed_slot", f.latestConfirmedSlot))
+	f.checkForks(ctx, latestFinalizedSlot)
 
-	blockResult, skip, err := f.fetch(ctx, requestedSlot, f.latestConfirmedSlot)
+	f.logger.Info("fetcher fetching block", zap.Uint64("block_num", requestedSlot), zap.Uint64("latest_finalized_slot", latestFinalizedSlot), zap.Uint64("latest_confirmed_slot", latestConfirmedSlot))
+
+	var blockResult *rpc.GetBlockResult
+	switch status, emitted := f.forks.status(requestedSlot); {
+	case emitted:
+		// The poller asks again for a block it was given, following the fork it belongs to,
+		// it is fetched again at finalized commitment once possible
+		opts := f.requestConfig.getBlockOpts()
+		if requestedSlot <= latestFinalizedSlot {
+			opts.Commitment = rpc.CommitmentFinalized
+		}
+		f.logger.Info("fetcher fetching emitted block again", zap.Uint64("block_num", requestedSlot), zap.Stringer("status", status), zap.String("commitment", string(opts.Commitment)))
+		blockResult, skip, err = f.fetch(ctx, requestedSlot, latestConfirmedSlot, opts)
+	default:
+		blockResult, skip, err = f.prefetch(ctx, requestedSlot, latestConfirmedSlot)
+	}
 	if err != nil {
 		return nil, false, fmt.Errorf("fetching block %d: %w", requestedSlot, err)
 	}
@@ -110,76 +220,304 @@ func (f *RPCFetcher) Fetch(ctx context.Context, requestedSlot uint64) (out *pbbs
 	}
 
 	if blockResult == nil {
-		panic("blockResult is nil and skip is false. This should not happen.")
+		return nil, false, fmt.Errorf("fetching block %d: no block returned", requestedSlot)
 	}
 
-	block, err := blockFromBlockResult(requestedSlot, f.latestFinalizedSlot, blockResult, f.logger)
+	block, err := blockFromBlockResult(requestedSlot, latestFinalizedSlot, blockResult, f.conversionPolicy, f.patches, f.logger)
 	if err != nil {
-		return nil, false, fmt.Errorf("decoding block %d: %w", requestedSlot, err)
+		// Decoding the same result again would fail the same way
+		return nil, false, derr.NewFatalError(fmt.Errorf("decoding block %d: %w", requestedSlot, err))
+	}
+
+	if requestedSlot > latestFinalizedSlot {
+		f.forks.emit(requestedSlot, blockResult.Blockhash.String(), blockResult.PreviousBlockhash.String(), forkPending)
+	} else if _, emitted := f.forks.status(requestedSlot); emitted {
+		f.forks.emit(requestedSlot, blockResult.Blockhash.String(), blockResult.PreviousBlockhash.String(), forkFinalized)
 	}
 
 	f.logger.Info("fetcher fetched block", zap.Uint64("block_num", requestedSlot), zap.String("block_hash", blockResult.Blockhash.String()))
 	return block, false, nil
 }
 
-func (f *RPCFetcher) fetch(ctx context.Context, requestedSlot uint64, lastConfirmBlockNum uint64) (*rpc.GetBlockResult, bool, error) {
-	currentSlot := requestedSlot
-	var lastErrorPrintedAt time.Time
+// waitForSlot blocks until the requested slot is confirmed, or finalized when only finalized
+// slots are fetched, and returns the latest confirmed and finalized slots. When only finalized
+// slots are fetched, the latest confirmed slot is the latest finalized one. headLock is only
+// held to read and record the head, concurrent Fetch calls for slots already confirmed are not
+// held up by one waiting for the head. With a live head tracker, the slots it pushes are used
+// as is, getSlot being only called while it is not live.
+func (f *RPCFetcher) waitForSlot(ctx context.Context, requestedSlot uint64) (latestConfirmedSlot uint64, latestFinalizedSlot uint64, err error) {
+	for attempt := 0; ; attempt++ {
+		latestConfirmedSlot, latestFinalizedSlot = f.head()
+		if latestConfirmedSlot >= requestedSlot {
+			break
+		}
 
-	for {
-		out, err := firecoreRPC.WithClients(f.rpcClients, func(client *rpc.Client) (*rpc.GetBlockResult, error) {
-			f.logger.Info("calling GetBlockWithOptions", zap.String("endpoints", fmt.Sprintf("%s", client)))
-			blockResult, err := client.GetBlockWithOpts(ctx, currentSlot, GetBlockOpts)
-			return blockResult, err
-		})
+		if err := f.waitForHead(ctx, requestedSlot, attempt); err != nil {
+			return 0, 0, err
+		}
+
+		if f.requestConfig.finalizedOnly() {
+			latestFinalizedSlot, err = f.updateFinalizedSlot(ctx, requestedSlot, latestFinalizedSlot)
+			if err != nil {
+				return 0, 0, err
+			}
+			f.recordHead(latestFinalizedSlot, latestFinalizedSlot)
+			continue
+		}
+
+		if confirmed, ok := f.trackedConfirmedSlot(); ok {
+			f.recordHead(confirmed, 0)
+			continue
+		}
+
+		latestConfirmedSlot, err = f.slotClients.getSlot(ctx, rpc.CommitmentConfirmed)
+		if err != nil {
+			return 0, 0, fmt.Errorf("fetching latestConfirmedSlot block num: %w", err)
+		}
+		f.recordHead(latestConfirmedSlot, 0)
+
+		f.logger.Info("got latest confirmed slot block", zap.Uint64("latest_confirmed_slot", latestConfirmedSlot), zap.Uint64("requested_block_num", requestedSlot))
+	}
+
+	latestFinalizedSlot, err = f.updateFinalizedSlot(ctx, requestedSlot, latestFinalizedSlot)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return latestConfirmedSlot, latestFinalizedSlot, nil
+}
+
+// head returns the latest confirmed and finalized slots known.
+func (f *RPCFetcher) head() (latestConfirmedSlot uint64, latestFinalizedSlot uint64) {
+	f.headLock.Lock()
+	defer f.headLock.Unlock()
+	return f.latestConfirmedSlot, f.latestFinalizedSlot
+}
+
+// recordHead records the latest confirmed and finalized slots seen, concurrent calls never
+// moving the head backward.
+func (f *RPCFetcher) recordHead(latestConfirmedSlot uint64, latestFinalizedSlot uint64) {
+	f.headLock.Lock()
+	defer f.headLock.Unlock()
+	f.latestConfirmedSlot = max(f.latestConfirmedSlot, latestConfirmedSlot)
+	f.latestFinalizedSlot = max(f.latestFinalizedSlot, latestFinalizedSlot)
+}
 
+// updateFinalizedSlot returns the latest finalized slot, taken from the head tracker when live
+// or fetched when latestFinalizedSlot is below the requested slot otherwise, and records it.
+func (f *RPCFetcher) updateFinalizedSlot(ctx context.Context, requestedSlot uint64, latestFinalizedSlot uint64) (uint64, error) {
+	if finalized, ok := f.trackedFinalizedSlot(); ok {
+		latestFinalizedSlot = max(latestFinalizedSlot, finalized)
+	} else if latestFinalizedSlot < requestedSlot {
+		finalized, err := f.slotClients.getSlot(ctx, rpc.CommitmentFinalized)
 		if err != nil {
-			var rpcErr *jsonrpc.RPCError
-			if errors.As(err, &rpcErr) {
-
-				if rpcErr.Code == -32009 || rpcErr.Code == -32007 {
-					f.logger.Info("fetcher block was skipped", zap.Uint64("block_num", currentSlot))
-					return nil, true, nil
-				}
-
-				if rpcErr.Code == -32004 {
-					if currentSlot < lastConfirmBlockNum {
-						f.logger.Info("fetcher block was supposedly skipped", zap.Uint64("block_num", currentSlot))
-						return nil, true, nil
-					}
-
-					f.logger.Warn("block not available. trying same block", zap.Uint64("block_num", currentSlot))
-					continue
-				}
+			return 0, fmt.Errorf("fetching latest finalized Slot block num: %w", err)
+		}
+		latestFinalizedSlot = max(latestFinalizedSlot, finalized)
+		f.logger.Info("got latest finalized slot block", zap.Uint64("latest_finalized_slot", finalized), zap.Uint64("requested_block_num", requestedSlot))
+	}
+
+	f.recordHead(0, latestFinalizedSlot)
+	return latestFinalizedSlot, nil
+}
+
+// waitForHead waits before checking the head again. With a live head tracker, it waits until
+// the requested slot was confirmed, or finalized when only finalized slots are fetched,
+// falling back to polling each latestBlockRetryInterval otherwise.
+func (f *RPCFetcher) waitForHead(ctx context.Context, requestedSlot uint64, attempt int) error {
+	if f.headTracker != nil {
+		if confirmed, finalized, live := f.headTracker.Head(); live {
+			if f.requestConfig.finalizedOnly() {
+				confirmed = finalized
+			}
+			if attempt == 0 && confirmed >= requestedSlot {
+				return nil
 			}
+			// Returns early on timeout or disconnection, the head being polled once disconnected
+			return f.headTracker.waitForSlot(ctx, requestedSlot, f.requestConfig.finalizedOnly(), f.latestBlockRetryInterval)
+		}
+	}
+
+	if attempt == 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(f.latestBlockRetryInterval):
+		return nil
+	}
+}
+
+func (f *RPCFetcher) trackedConfirmedSlot() (uint64, bool) {
+	if f.headTracker == nil {
+		return 0, false
+	}
+	confirmed, _, live := f.headTracker.Head()
+	return confirmed, live && confirmed > 0
+}
+
+func (f *RPCFetcher) trackedFinalizedSlot() (uint64, bool) {
+	if f.headTracker == nil {
+		return 0, false
+	}
+	_, finalized, live := f.headTracker.Head()
+	return finalized, live && finalized > 0
+}
+
+// prefetch returns the requested slot's block, fetching it if no prefetch was already started
+// for it, and starts fetching the following confirmed slots of the prefetch window.
+func (f *RPCFetcher) prefetch(ctx context.Context, requestedSlot uint64, latestConfirmedSlot uint64) (*rpc.GetBlockResult, bool, error) {
+	f.prefetchLock.Lock()
+	for slot := range f.prefetched {
+		// The poller moved past those, it will fetch them again if it ever needs them
+		if slot+uint64(f.prefetchWindow) < requestedSlot {
+			delete(f.prefetched, slot)
+		}
+	}
 
-			if lastErrorPrintedAt.IsZero() || time.Since(lastErrorPrintedAt) > 30*time.Second {
-				f.logger.Warn("error getting block", zap.Uint64("block_num", currentSlot), zap.Error(err))
-				lastErrorPrintedAt = time.Now()
+	requested := f.startFetchLocked(requestedSlot, latestConfirmedSlot)
+	for slot := requestedSlot + 1; slot < requestedSlot+uint64(f.prefetchWindow) && slot <= latestConfirmedSlot; slot++ {
+		f.startFetchLocked(slot, latestConfirmedSlot)
+	}
+	f.prefetchLock.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	case <-requested.done:
+	}
+
+	f.prefetchLock.Lock()
+	if f.prefetched[requestedSlot] == requested {
+		delete(f.prefetched, requestedSlot)
+	}
+	f.prefetchLock.Unlock()
+
+	return requested.result, requested.skip, requested.err
+}
+
+// startFetchLocked must be called with prefetchLock held.
+func (f *RPCFetcher) startFetchLocked(slot uint64, latestConfirmedSlot uint64) *prefetchedBlock {
+	if item, found := f.prefetched[slot]; found {
+		return item
+	}
+
+	item := &prefetchedBlock{done: make(chan struct{})}
+	f.prefetched[slot] = item
+
+	// Prefetches outlive the call that started them, they are only canceled when the fetcher is
+	// closed
+	go func() {
+		defer close(item.done)
+		item.result, item.skip, item.err = f.fetch(f.ctx, slot, latestConfirmedSlot, f.requestConfig.getBlockOpts())
+	}()
+
+	return item
+}
+
+// fetch retries getting the block with opts, backing off between attempts, until every endpoint fails
+// with a permanent error or the retry budget is exhausted. The returned *FetchError, or
+// *UnsupportedTransactionVersionError, is marked as fatal so the poller does not retry it again.
+func (f *RPCFetcher) fetch(ctx context.Context, requestedSlot uint64, lastConfirmBlockNum uint64, opts *rpc.GetBlockOpts) (*rpc.GetBlockResult, bool, error) {
+	for attempt := 1; ; attempt++ {
+		// Spread consecutive slots across endpoints, the others being used as fallbacks
+		out, err := withClients(f.blockClients, requestedSlot, func(client *rpc.Client) (*rpc.GetBlockResult, error) {
+			f.logger.Debug("calling GetBlockWithOptions", zap.Uint64("block_num", requestedSlot))
+			blockResult, err := client.GetBlockWithOpts(ctx, requestedSlot, opts)
+			return blockResult, err
+		})
+		if err == nil {
+			if err := f.requestConfig.checkTransactionVersions(requestedSlot, out); err != nil {
+				return nil, false, derr.NewFatalError(err)
 			}
+			return out, false, nil
+		}
+
+		var endpointsErr *EndpointsError
+		if !errors.As(err, &endpointsErr) {
+			return nil, false, err
+		}
+
+		if f.isSkipped(endpointsErr, requestedSlot, lastConfirmBlockNum) {
+			return nil, true, nil
+		}
+
+		if detail, found := unsupportedTransactionVersion(endpointsErr); found {
+			return nil, false, derr.NewFatalError(&UnsupportedTransactionVersionError{Slot: requestedSlot, MaxSupportedTransactionVersion: f.requestConfig.MaxSupportedTransactionVersion, Detail: detail})
+		}
+
+		if permanent := endpointsErr.Permanent(); permanent || f.retryConfig.exhausted(attempt) {
+			return nil, false, derr.NewFatalError(&FetchError{Slot: requestedSlot, Attempts: attempt, Permanent: permanent, Last: endpointsErr})
+		}
 
-			//we retry forever!
+		delay := f.retryConfig.backoff(attempt)
+		f.logger.Warn("error getting block, retrying", zap.Uint64("block_num", requestedSlot), zap.Int("attempt", attempt), zap.Duration("retry_in", delay), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// isSkipped tells if one of the endpoints reported the slot as skipped. A slot not available
+// while being older than the latest confirmed one is considered skipped too.
+func (f *RPCFetcher) isSkipped(endpointsErr *EndpointsError, requestedSlot uint64, lastConfirmBlockNum uint64) bool {
+	for _, endpointErr := range endpointsErr.Errors {
+		var rpcErr *jsonrpc.RPCError
+		if !errors.As(endpointErr.Err, &rpcErr) {
 			continue
 		}
 
-		return out, false, nil
+		switch rpcErr.Code {
+		case rpcErrLongTermStorageSlotSkipped, rpcErrSlotSkipped:
+			f.logger.Info("fetcher block was skipped", zap.Uint64("block_num", requestedSlot), zap.String("endpoint", endpointErr.Endpoint))
+			return true
+		case rpcErrBlockNotAvailable:
+			if requestedSlot < lastConfirmBlockNum {
+				f.logger.Info("fetcher block was supposedly skipped", zap.Uint64("block_num", requestedSlot), zap.String("endpoint", endpointErr.Endpoint))
+				return true
+			}
+		}
 	}
+	return false
 }
 
-func blockFromBlockResult(slot uint64, finalizedSlot uint64, result *rpc.GetBlockResult, logger *zap.Logger) (*pbbstream.Block, error) {
+// unsupportedTransactionVersion returns the rejection of the endpoint refusing to send a block
+// because of a transaction version above the requested maximum, if any.
+func unsupportedTransactionVersion(endpointsErr *EndpointsError) (string, bool) {
+	for _, endpointErr := range endpointsErr.Errors {
+		var rpcErr *jsonrpc.RPCError
+		if errors.As(endpointErr.Err, &rpcErr) && rpcErr.Code == rpcErrUnsupportedTransactionVersion {
+			return endpointErr.Error(), true
+		}
+	}
+	return "", false
+}
+
+func blockFromBlockResult(slot uint64, finalizedSlot uint64, result *rpc.GetBlockResult, conversionPolicy ConversionPolicy, chainPatches *patches.Registry, logger *zap.Logger) (*pbbstream.Block, error) {
 	libNum := finalizedSlot
 
 	if finalizedSlot > slot {
 		libNum = result.ParentSlot
 	}
 
-	fixedPreviousBlockHash := fixPreviousBlockHash(result, logger)
+	fixedPreviousBlockHash := fixPreviousBlockHash(result, chainPatches, logger)
 
-	transactions, err := toPbTransactions(result.Transactions)
+	converter := &rpcConverter{policy: conversionPolicy, logger: logger.With(zap.Uint64("block_num", slot))}
+	transactions, err := converter.toPbTransactions(result.Transactions)
 	if err != nil {
 		return nil, fmt.Errorf("decoding transactions: %w", err)
 	}
 
+	rewards, err := converter.toPBReward("rewards", result.Rewards)
+	if err != nil {
+		return nil, fmt.Errorf("decoding rewards: %w", err)
+	}
+
 	var blockTime *pbsol.UnixTimestamp
 	if result.BlockTime != nil {
 		blockTime = pbsol.NewUnixTimestamp(result.BlockTime.Time())
@@ -196,10 +534,11 @@ func blockFromBlockResult(slot uint64, finalizedSlot uint64, result *rpc.GetBloc
 		Blockhash:         result.Blockhash.String(),
 		ParentSlot:        result.ParentSlot,
 		Transactions:      transactions,
-		Rewards:           toPBReward(result.Rewards),
+		Rewards:           rewards,
 		BlockTime:         blockTime,
 		BlockHeight:       blockHeight,
 		Slot:              slot,
+		UnknownValues:     converter.unknown,
 	}
 
 	payload, err := anypb.New(block)
@@ -225,41 +564,8 @@ func blockFromBlockResult(slot uint64, finalizedSlot uint64, result *rpc.GetBloc
 
 }
 
-var blockToPatch = map[string]string{
-	"2TLDT6Z3WJ5h5958BjdzMwmNGnVo3e4qcHyGBVgBPDm9": "FCgBdK9Fufcsdc9RGu5SwMwbCFiw4SxNnJzCpZTdNpDq",
-	"AACrVjKzuvTLxmjC7Ktn5St1GkFHd9smvNHDf2dCPF5o": "4hkGhNnuuCFqD3w35KXJJvDyfkbSQHZgrUn7SKc7xy76",
-	"Dp1h2oCTMisFbT2EVmpd8thzEZA59sHy5cVEWpnAW9nK": "AkKwmqftQux3tBPZMkHUFEG1ShRj86my3PtLcsXiPEhC",
-	"3fDddQ4CMS1v2AQRhzTKoUV7bhUj7FnrrhgCR27sxbwL": "2hcGqX4YBiD5jcj3AvWCk2DNjN7gL8trnGVdBJ1bmHLw",
-	"HSrRb3iwKJafacyaDGY3U1UTEcW8fnR6RKJeXbcnjjJL": "bFjEKnrEytyyfgfvPn46tXYMjqyERfq5yQW8wETMj5b",
-	"4mjTfuxGqczLL2hHDsJc86Ni3GuiVHyRZ76xWzYEp1rd": "EWL4JSYcGNdgqEKc7LceGbSkLgLRvJPLxruXHaE5LBXz",
-	"FexbnjDrAshJSGUETr8DoB8hUrVbmEe9frxZuZE3YLj6": "5HP9qtrjKkYQgqijkkRJJUYY3EunSYWi7vH8RRoqVE5B",
-	"4kM9y9ucKjfoTmjt41Qn83xpZbBUgLDAbVfySKbyUcRD": "CAHggyj6n8ytmb5peziHDTaPyQGAjDuNRdBZhzNAPYUC",
-	"GHpc3nirTs9fj95andWPw4QX4jwi3Uu4NLJEKbWJ5YmX": "A8p44eo3n9nEyaCv5Mfa77DfJky2m5i6XRdZwBAqADM8",
-	"DhomkvG22nCYqwhoghtpsaoUsNwE3Sd5rP3uWDKk3dcd": "8CZuKdcphp4vs5emnNyPeguLLCtdCwyh4hmEy6XtZ9uo",
-	"Hde6FztxXayXcySpHdtNGK9HJGoNGzmDPLreqv1ocQJr": "AC27cduJoqJu86ETpajJmrwktZko5epUr1ezfLhx1Vzt",
-	"8VJJtvfTo5ixbEA4YyvuLiUQdi1x3fgeY78hhLohD9Dq": "77Wa7nyGcDJnY8wWhVWBAjzpxr3ovqH265ZpVjX6N4LA",
-	"GnjK9dG91VpLkWPNFZwqv6Dyp6FSVtgTTgcZp1Fc8gbU": "AGuB4sQ2xBQ7qLxHAE1jEDbEQun76LRVPpbsU7TA2Ceu",
-	"2p5J7RpEAcv7S1rFdubBd2Jsxk5A9gsPZPYEeZ3AFmPb": "37duR4zVdkmBDQf7nRbWwabzWdCTNY4YxrT7BXQCRnSz",
-	"GyRKbxESPzwQgzY9HGKCahzyCoYE5LPcAeybL5JA43Cv": "6A7Thgk1sWmX5RhygsqxsVKzXyRJTtUVrYCz5fjZEb6x",
-	"BXnB4SLEKHJiUeM6CShcQhG5kC5mXY6xmnzPYnU64sWH": "FyUTMMDb8u7jcQeuouoZ1JS72dbuqxeBvf8PFEZeXwBn",
-	"BTFfa2oTTsCecqmj8JVn5gdpLazAh58mqJFHCkiLMKHF": "CQzukbEBmT9Kf2VCeW8oV7ASvRitZwcB4tVdwdm3VmGN",
-	"7wwDCCNA9EfLiZQzzBZTZKPsJisWxVHNUbKkSWjmnaHg": "6UDRkQfuAHwtMyZVBi8ACoqk2HeumjhVBtGiLGfkwTMD",
-	"C8qCiSUrvjAcGizHDDUHwCfYYZnqDb6tnzsg7XYBKCZ3": "GnN1RorTCy3DCzCJxZjPjSQZaX2JvFbF1g7dMCMMmCiB",
-	"FBnxhciRnEKEtpwX7VRQLv55xCEmTHtRy7fEvjid2W8S": "A85UR6HfVfdNezrSBc7fQiNtbfZrYWXtEbjMuW3Mnqt7",
-	"9WgaJZbYTD4WpTQnQtia87xbb9y3iqsdWJNVzcLG7rX9": "9YWWR5h3tPHKgm6ZpGAHisdeYGyjtNbwXiyLUvKzqP3H",
-	"3BzDxbNwCgtCDdg74KC6LFnDJVAyJeqtSwKuFL4cufx9": "9d8LkjdgGxVJfB5PUnhFUr2r7w8hMmKeoEQg7Kg2jFiJ",
-	"CKL5Pd6f85jtdrLibnbVtnY8VatS9rjBsX8ztD6wAfdj": "7uBfGie2UTW7sUfnZvvYbXi1y5BTUdUWTYhX2WxhYTSf",
-	"7x3cd4zzTMn9ixa4unPN2UGi4ctU8qEpqVAwyC9dwvZe": "4VoUxo2RrJ1reriLMUzuA3VaKuGKqMszXY7KDt6i4cpP",
-	"Goi3t9JjgDkyULZbM2TzE5QqHP1fPeMcHNaXNFBCBv1v": "HQEr9qcbUVBt2okfu755FdJvJrPYTSpzzmmyeWTj5oau",
-	"6UFQveZ94DUKGbcLFoyayn1QwthVfD3ZqvrM2916pHCR": "7cLQx2cZvyKbGoMuutXEZ3peg3D21D5qbX19T5V1XEiK",
-	"Fqbm7QvCTYnToXWcCw6nbkWhMmXx2Nv91LsXBrKraB43": "RfXUrekgajPSb1R4CGFJWNaHTnB6p53Tzert4gouj2u",
-	"ABp9G2NaPzM6kQbeyZYCYgdzL8JN9AxSSbCQG2X1K9UF": "9F2C7TGqUpFu6krd8vQbUv64BskrneBSgY7U2QfrGx96",
-	"ByUxmGuaT7iQS9qGS8on5xHRjiHXcGxvwPPaTGZXQyz7": "J6rRToKMK5DQDzVLqo7ibL3snwBYtqkYnRnQ7vXoUSEc",
-	"FdDcjfaErqwgGdoZBSJWvMPHh7qd7jr7p9TpEht6AJvb": "V7euK9EAB5YLuQVyeEHynevUthkNPRbsvHHMoAHNnE2",
-}
-
-func fixPreviousBlockHash(blockResult *rpc.GetBlockResult, logger *zap.Logger) (previousFixedBlockHash string) {
-	if prev, ok := blockToPatch[blockResult.Blockhash.String()]; ok {
+func fixPreviousBlockHash(blockResult *rpc.GetBlockResult, chainPatches *patches.Registry, logger *zap.Logger) (previousFixedBlockHash string) {
+	if prev, ok := chainPatches.PreviousBlockhash(blockResult.Blockhash.String()); ok {
 		logger.Info("patching previous block hash", zap.String("block_hash", blockResult.Blockhash.String()), zap.String("previous_block_hash", prev))
 		return prev
 
@@ -267,24 +573,28 @@ func fixPreviousBlockHash(blockResult *rpc.GetBlockResult, logger *zap.Logger) (
 	return blockResult.PreviousBlockhash.String()
 }
 
-func toPbTransactions(transactions []rpc.TransactionWithMeta) (out []*pbsol.ConfirmedTransaction, err error) {
-	for _, transaction := range transactions {
-		meta, err := toPbTransactionMeta(transaction.Meta)
+func (c *rpcConverter) toPbTransactions(transactions []rpc.TransactionWithMeta) (out []*pbsol.ConfirmedTransaction, err error) {
+	for i, transaction := range transactions {
+		path := fmt.Sprintf("transactions[%d]", i)
+		meta, err := c.toPbTransactionMeta(path+".meta", transaction.Meta)
 		if err != nil {
 			return nil, fmt.Errorf(`decoding transaction meta: %w`, err)
 		}
+		trx, err := c.toPbTransaction(path+".transaction", transaction)
 		if err != nil {
 			return nil, fmt.Errorf(`decoding transaction: %w`, err)
 		}
 		out = append(out, &pbsol.ConfirmedTransaction{
-			Transaction: toPbTransaction(transaction.MustGetTransaction()),
+			Transaction: trx,
 			Meta:        meta,
 		})
 	}
 	return
 }
 
-func toPbTransactionMeta(meta *rpc.TransactionMeta) (*pbsol.TransactionStatusMeta, error) {
+// toPbTransactionMeta converts meta, the RPC omitting, or sending null for, inner instructions and
+// log messages that were not recorded, which the JSON decoding leaves nil unlike empty ones.
+func (c *rpcConverter) toPbTransactionMeta(path string, meta *rpc.TransactionMeta) (*pbsol.TransactionStatusMeta, error) {
 	if meta == nil {
 		return &pbsol.TransactionStatusMeta{}, nil
 	}
@@ -293,22 +603,37 @@ func toPbTransactionMeta(meta *rpc.TransactionMeta) (*pbsol.TransactionStatusMet
 		return nil, fmt.Errorf("decoding return data: %w", err)
 	}
 
-	innerInstructions := toPbInnerInstructions(meta.InnerInstructions)
+	innerInstructions, err := c.toPbInnerInstructions(path+".inner_instructions", meta.InnerInstructions)
+	if err != nil {
+		return nil, fmt.Errorf("decoding inner instructions: %w", err)
+	}
 
 	trxErr, err := toPbTransactionError(meta.Err)
+	if err != nil {
+		return nil, fmt.Errorf("decoding transaction error: %w", err)
+	}
+
+	rewards, err := c.toPBReward(path+".rewards", meta.Rewards)
+	if err != nil {
+		return nil, fmt.Errorf("decoding rewards: %w", err)
+	}
+
 	return &pbsol.TransactionStatusMeta{
 		Err:                     trxErr,
 		Fee:                     meta.Fee,
 		PreBalances:             meta.PreBalances,
 		PostBalances:            meta.PostBalances,
 		InnerInstructions:       innerInstructions,
+		InnerInstructionsNone:   meta.InnerInstructions == nil,
 		LogMessages:             meta.LogMessages,
+		LogMessagesNone:         meta.LogMessages == nil,
 		PreTokenBalances:        toPbTokenBalances(meta.PreTokenBalances),
 		PostTokenBalances:       toPbTokenBalances(meta.PostTokenBalances),
-		Rewards:                 toPBReward(meta.Rewards),
+		Rewards:                 rewards,
 		LoadedWritableAddresses: toPbWritableAddresses(meta.LoadedAddresses.Writable),
 		LoadedReadonlyAddresses: toPbReadonlyAddresses(meta.LoadedAddresses.ReadOnly),
 		ReturnData:              returnData,
+		ReturnDataNone:          returnData == nil,
 		ComputeUnitsConsumed:    meta.ComputeUnitsConsumed,
 	}, nil
 }
@@ -352,6 +677,15 @@ func toPbWritableAddresses(writableAddresses solana.PublicKeySlice) [][]byte {
 	return out
 }
 
+// tokenBalanceProgramId returns programId as blocks hold it, empty when unknown: RPC token
+// balances lacking one decode as the zero public key, the system program id.
+func tokenBalanceProgramId(programId string) string {
+	if programId == "11111111111111111111111111111111" {
+		return ""
+	}
+	return programId
+}
+
 func toPbTokenBalances(balances []rpc.TokenBalance) []*pbsol.TokenBalance {
 	var out []*pbsol.TokenBalance
 
@@ -361,11 +695,7 @@ func toPbTokenBalances(balances []rpc.TokenBalance) []*pbsol.TokenBalance {
 			owner = balance.Owner.String()
 		}
 
-		var programId string
-
-		if balance.ProgramId.String() != "11111111111111111111111111111111" {
-			programId = balance.ProgramId.String()
-		}
+		programId := tokenBalanceProgramId(balance.ProgramId.String())
 
 		out = append(out, &pbsol.TokenBalance{
 			AccountIndex:  uint32(balance.AccountIndex),
@@ -394,26 +724,26 @@ func toPbUiTokenAmount(amount *rpc.UiTokenAmount) *pbsol.UiTokenAmount {
 	}
 }
 
-func toPbInnerInstructions(instructions []rpc.InnerInstruction) []*pbsol.InnerInstructions {
+func (c *rpcConverter) toPbInnerInstructions(path string, instructions []rpc.InnerInstruction) ([]*pbsol.InnerInstructions, error) {
 	var out []*pbsol.InnerInstructions
-	for _, instruction := range instructions {
+	for i, instruction := range instructions {
+		innerInstructions, err := c.compileInstructionsToPbInnerInstructionArray(fmt.Sprintf("%s[%d].instructions", path, i), instruction.Instructions)
+		if err != nil {
+			return nil, err
+		}
 		out = append(out, &pbsol.InnerInstructions{
 			Index:        uint32(instruction.Index),
-			Instructions: compileInstructionsToPbInnerInstructionArray(instruction.Instructions),
+			Instructions: innerInstructions,
 		})
 	}
-	return out
+	return out, nil
 }
 
-func compileInstructionsToPbInnerInstructionArray(instructions []solana.CompiledInstruction) (out []*pbsol.InnerInstruction) {
-	for _, compiledInstruction := range instructions {
-
-		var accounts []byte
-		for _, account := range compiledInstruction.Accounts {
-			if account > math.MaxUint8 {
-				panic("received instruction with account index greater then 256")
-			}
-			accounts = append(accounts, byte(account))
+func (c *rpcConverter) compileInstructionsToPbInnerInstructionArray(path string, instructions []solana.CompiledInstruction) (out []*pbsol.InnerInstruction, err error) {
+	for i, compiledInstruction := range instructions {
+		accounts, err := c.toPbAccountIndexes(fmt.Sprintf("%s[%d].accounts", path, i), compiledInstruction.Accounts)
+		if err != nil {
+			return nil, err
 		}
 
 		out = append(out, &pbsol.InnerInstruction{
@@ -426,6 +756,19 @@ func compileInstructionsToPbInnerInstructionArray(instructions []solana.Compiled
 	return
 }
 
+// toPbAccountIndexes converts the account indexes of an instruction, which are u8 on chain. The
+// unknown marker is no accounts.
+func (c *rpcConverter) toPbAccountIndexes(path string, indexes []uint16) ([]byte, error) {
+	var accounts []byte
+	for _, index := range indexes {
+		if index > math.MaxUint8 {
+			return nil, c.unknownValue(path, indexes, fmt.Errorf("account index %d is greater than 255", index))
+		}
+		accounts = append(accounts, byte(index))
+	}
+	return accounts, nil
+}
+
 func toStackHeight(stackHeight uint32) *uint32 {
 	if stackHeight == 0 {
 		return nil
@@ -434,16 +777,21 @@ func toStackHeight(stackHeight uint32) *uint32 {
 	return &s
 }
 
+// toPbTransactionError bincode encodes the transaction error, unknown variants being kept with a
+// reserved code, see TrxErr_Unknown.
 func toPbTransactionError(e interface{}) (*pbsol.TransactionError, error) {
 	if e == nil {
 		return nil, nil
 	}
 
-	txErr := MustNewTransactionError(e)
+	txErr, err := NewTransactionError(e)
+	if err != nil {
+		return nil, err
+	}
+
 	buf := bytes.NewBuffer(nil)
 	encoder := bin.NewEncoder(buf)
-	err := txErr.Encode(encoder)
-	if err != nil {
+	if err := txErr.Encode(encoder); err != nil {
 		return nil, err
 	}
 	return &pbsol.TransactionError{
@@ -451,34 +799,57 @@ func toPbTransactionError(e interface{}) (*pbsol.TransactionError, error) {
 	}, nil
 }
 
-func toPbTransaction(transaction *solana.Transaction) *pbsol.Transaction {
-	return &pbsol.Transaction{
-		Signatures: toPbSignatures(transaction.Signatures),
-		Message:    toPbMessage(transaction.Message),
+// toPbTransaction decodes the transaction payload, the unknown marker being an empty
+// transaction.
+func (c *rpcConverter) toPbTransaction(path string, transactionWithMeta rpc.TransactionWithMeta) (*pbsol.Transaction, error) {
+	if transactionWithMeta.Transaction == nil {
+		if err := c.unknownValue(path, nil, fmt.Errorf("transaction is missing")); err != nil {
+			return nil, err
+		}
+		return &pbsol.Transaction{}, nil
+	}
+
+	transaction, err := transactionWithMeta.GetTransaction()
+	if err != nil {
+		if err := c.unknownValue(path, transactionWithMeta.Transaction, err); err != nil {
+			return nil, err
+		}
+		return &pbsol.Transaction{}, nil
+	}
+
+	message, err := c.toPbMessage(path+".message", transaction.Message)
+	if err != nil {
+		return nil, err
 	}
 
+	return &pbsol.Transaction{
+		Signatures: toPbSignatures(transaction.Signatures),
+		Message:    message,
+	}, nil
 }
 
-func toPbMessage(message solana.Message) *pbsol.Message {
+func (c *rpcConverter) toPbMessage(path string, message solana.Message) (*pbsol.Message, error) {
+	instructions, err := c.toPbInstructions(path+".instructions", message.Instructions)
+	if err != nil {
+		return nil, err
+	}
+
 	return &pbsol.Message{
 		Header:              toPbMessageHeader(message.Header),
 		AccountKeys:         toPbAccountKeys(message.AccountKeys),
 		RecentBlockhash:     message.RecentBlockhash[:],
-		Instructions:        toPbInstructions(message.Instructions),
+		Instructions:        instructions,
 		Versioned:           message.IsVersioned(),
 		AddressTableLookups: toPbAddressTableLookups(message.AddressTableLookups),
-	}
+	}, nil
 }
 
-func toPbInstructions(instructions []solana.CompiledInstruction) []*pbsol.CompiledInstruction {
+func (c *rpcConverter) toPbInstructions(path string, instructions []solana.CompiledInstruction) ([]*pbsol.CompiledInstruction, error) {
 	var out []*pbsol.CompiledInstruction
-	for _, instruction := range instructions {
-		var accounts []byte
-		for _, account := range instruction.Accounts {
-			if account > math.MaxUint8 {
-				panic("received instruction with account index greater then 256")
-			}
-			accounts = append(accounts, byte(account))
+	for i, instruction := range instructions {
+		accounts, err := c.toPbAccountIndexes(fmt.Sprintf("%s[%d].accounts", path, i), instruction.Accounts)
+		if err != nil {
+			return nil, err
 		}
 		out = append(out, &pbsol.CompiledInstruction{
 			ProgramIdIndex: uint32(instruction.ProgramIDIndex),
@@ -486,7 +857,7 @@ func toPbInstructions(instructions []solana.CompiledInstruction) []*pbsol.Compil
 			Data:           instruction.Data,
 		})
 	}
-	return out
+	return out, nil
 }
 
 func toPbAddressTableLookups(addressTableLookups solana.MessageAddressTableLookupSlice) (out []*pbsol.MessageAddressTableLookup) {
@@ -530,34 +901,62 @@ func toPbSignatures(signatures []solana.Signature) (out [][]byte) {
 	return
 }
 
-func toPBReward(rewards []rpc.BlockReward) (out []*pbsol.Reward) {
-	for _, reward := range rewards {
+// toPBReward converts rewards, paths of unknown values using their index as returned by the
+// RPC, before sorting. The unknown marker of a reward type is RewardType_Unspecified.
+func (c *rpcConverter) toPBReward(path string, rewards []rpc.BlockReward) (out []*pbsol.Reward, err error) {
+	for i, reward := range rewards {
+		rewardType, err := toPBRewardType(reward.RewardType)
+		if err != nil {
+			if err := c.unknownValue(fmt.Sprintf("%s[%d].reward_type", path, i), reward.RewardType, err); err != nil {
+				return nil, err
+			}
+		}
+
 		out = append(out, &pbsol.Reward{
 			Pubkey:      reward.Pubkey.String(),
 			Lamports:    reward.Lamports,
 			PostBalance: reward.PostBalance,
-			RewardType:  toPBRewardType(reward.RewardType),
+			RewardType:  rewardType,
+			Commission:  toPBCommission(reward.Commission),
 		})
 	}
 
-	slices.SortFunc(out, func(a, b *pbsol.Reward) int {
+	SortRewards(out)
+	return
+}
+
+// SortRewards sorts rewards by lamports, the order of the blocks produced from every source,
+// rewards of equal lamports keeping the validator's order.
+func SortRewards(rewards []*pbsol.Reward) {
+	slices.SortStableFunc(rewards, func(a, b *pbsol.Reward) int {
 		return cmp.Compare(a.Lamports, b.Lamports)
 	})
+}
 
-	return
+// toPBCommission renders commission the way Bigtable stores it, in decimal, empty when the
+// reward has none, which is the case of fee and rent rewards.
+func toPBCommission(commission *uint8) string {
+	if commission == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*commission), 10)
 }
 
-func toPBRewardType(rewardType rpc.RewardType) pbsol.RewardType {
+// toPBRewardType converts rewardType, which rewards stored before reward types were recorded
+// don't have.
+func toPBRewardType(rewardType rpc.RewardType) (pbsol.RewardType, error) {
 	switch rewardType {
+	case "":
+		return pbsol.RewardType_Unspecified, nil
 	case rpc.RewardTypeFee:
-		return pbsol.RewardType_Fee
+		return pbsol.RewardType_Fee, nil
 	case rpc.RewardTypeRent:
-		return pbsol.RewardType_Rent
+		return pbsol.RewardType_Rent, nil
 	case rpc.RewardTypeVoting:
-		return pbsol.RewardType_Voting
+		return pbsol.RewardType_Voting, nil
 	case rpc.RewardTypeStaking:
-		return pbsol.RewardType_Staking
+		return pbsol.RewardType_Staking, nil
 	default:
-		panic(fmt.Errorf("unsupported reward type %q", rewardType))
+		return pbsol.RewardType_Unspecified, fmt.Errorf("unsupported reward type %q", rewardType)
 	}
 }
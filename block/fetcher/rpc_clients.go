@@ -0,0 +1,359 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var ErrNoClients = errors.New("no rpc clients configured")
+
+// healthSmoothing is the weight of the latest observation in the latency and error rate
+// moving averages.
+const healthSmoothing = 0.2
+
+// rotationTolerance is how many times the best healthy endpoint's score another one can have
+// and still share its load.
+const rotationTolerance = 1.5
+
+type HealthConfig struct {
+	// MaxSlotLag is how many slots an endpoint can be behind the most advanced one before
+	// being considered unhealthy.
+	MaxSlotLag uint64
+	// MaxConsecutiveErrors is the number of failed calls in a row after which an endpoint is
+	// benched for BenchDuration.
+	MaxConsecutiveErrors int
+	BenchDuration        time.Duration
+}
+
+var DefaultHealthConfig = HealthConfig{
+	MaxSlotLag:           50,
+	MaxConsecutiveErrors: 3,
+	BenchDuration:        30 * time.Second,
+}
+
+type endpointHealth struct {
+	name              string
+	latency           time.Duration
+	errorRate         float64
+	consecutiveErrors int
+	lastSlot          uint64
+	benchedUntil      time.Time
+}
+
+// score is lower for better endpoints, latency is inflated by the error rate.
+func (h *endpointHealth) score() float64 {
+	return float64(h.latency.Milliseconds()+1) * (1 + 10*h.errorRate)
+}
+
+type endpointHealthLog struct {
+	endpointHealth
+	lag     uint64
+	benched bool
+}
+
+func (h endpointHealthLog) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	encoder.AddString("endpoint", h.name)
+	encoder.AddDuration("latency", h.latency)
+	encoder.AddFloat64("error_rate", h.errorRate)
+	encoder.AddUint64("slot", h.lastSlot)
+	encoder.AddUint64("slot_lag", h.lag)
+	encoder.AddBool("benched", h.benched)
+	return nil
+}
+
+// RPCClients is a set of endpoints an RPCFetcher talks to for a given purpose (slot tip
+// tracking or block fetching). Unlike firecoreRPC.Clients it holds no iteration state, so it
+// is safe to use from concurrent fetches, and it keeps track of each endpoint's health to
+// prefer fast, reliable and up-to-date endpoints.
+type RPCClients struct {
+	// pool identifies the set in logs and metrics, the same endpoint can be part of many sets
+	// with independent health
+	pool    string
+	clients []*rpc.Client
+	config  HealthConfig
+
+	lock    sync.Mutex
+	health  []*endpointHealth
+	maxSlot uint64
+}
+
+func NewRPCClients(pool string) *RPCClients {
+	return NewRPCClientsWithHealthConfig(pool, DefaultHealthConfig)
+}
+
+func NewRPCClientsWithHealthConfig(pool string, config HealthConfig) *RPCClients {
+	return &RPCClients{pool: pool, config: config}
+}
+
+func (c *RPCClients) Pool() string {
+	return c.pool
+}
+
+func (c *RPCClients) Add(name string, client *rpc.Client) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.clients = append(c.clients, client)
+	c.health = append(c.health, &endpointHealth{name: name})
+}
+
+func (c *RPCClients) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.clients)
+}
+
+// ordered returns the client indexes to try: healthy endpoints by increasing score, the ones
+// within rotationTolerance of the best score being rotated by offset to spread load amongst
+// them, followed by unhealthy ones as a last resort.
+func (c *RPCClients) ordered(offset uint64) []int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	var healthy, unhealthy []int
+	for i, h := range c.health {
+		if now.Before(h.benchedUntil) || c.lagLocked(h) > c.config.MaxSlotLag {
+			unhealthy = append(unhealthy, i)
+			continue
+		}
+		healthy = append(healthy, i)
+	}
+
+	byScore := func(indexes []int) {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return c.health[indexes[a]].score() < c.health[indexes[b]].score()
+		})
+	}
+	byScore(healthy)
+	byScore(unhealthy)
+
+	if len(healthy) > 0 {
+		rotated := 1
+		best := c.health[healthy[0]].score()
+		for rotated < len(healthy) && c.health[healthy[rotated]].score() <= best*rotationTolerance {
+			rotated++
+		}
+
+		shift := int(offset % uint64(rotated))
+		healthy = append(append(healthy[shift:rotated:rotated], healthy[:shift]...), healthy[rotated:]...)
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// lagLocked must be called with lock held. Endpoints that never reported a slot are not
+// considered lagging.
+func (c *RPCClients) lagLocked(h *endpointHealth) uint64 {
+	if h.lastSlot == 0 || h.lastSlot >= c.maxSlot {
+		return 0
+	}
+	return c.maxSlot - h.lastSlot
+}
+
+func (c *RPCClients) observe(idx int, latency time.Duration, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	h := c.health[idx]
+	failed := isEndpointFailure(err)
+
+	if h.latency == 0 {
+		h.latency = latency
+	} else {
+		h.latency = time.Duration(healthSmoothing*float64(latency) + (1-healthSmoothing)*float64(h.latency))
+	}
+
+	observedError := 0.0
+	if failed {
+		observedError = 1
+		h.consecutiveErrors++
+	} else {
+		h.consecutiveErrors = 0
+	}
+	h.errorRate = healthSmoothing*observedError + (1-healthSmoothing)*h.errorRate
+
+	if failed && h.consecutiveErrors >= c.config.MaxConsecutiveErrors {
+		h.benchedUntil = time.Now().Add(c.config.BenchDuration)
+		h.consecutiveErrors = 0
+	}
+
+	RPCEndpointRequestCount.Inc(c.pool, h.name, requestStatus(failed))
+	RPCEndpointLatency.SetFloat64(float64(h.latency.Milliseconds()), c.pool, h.name)
+	RPCEndpointErrorRate.SetFloat64(h.errorRate, c.pool, h.name)
+}
+
+func (c *RPCClients) observeSlot(idx int, slot uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.health[idx].lastSlot = slot
+	if slot > c.maxSlot {
+		c.maxSlot = slot
+	}
+}
+
+func requestStatus(failed bool) string {
+	if failed {
+		return "error"
+	}
+	return "success"
+}
+
+// isEndpointFailure tells if err is the endpoint's fault. JSON-RPC errors telling that a slot
+// was skipped or that its block is not available, and the ones about the request itself, are
+// proper answers, while other node errors (-32005 node unhealthy, -32603 internal error, ...),
+// transport errors and timeouts are not.
+func isEndpointFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rpcErr *jsonrpc.RPCError
+	if !errors.As(err, &rpcErr) {
+		return true
+	}
+
+	switch rpcErr.Code {
+	case rpcErrBlockCleanedUp, rpcErrBlockNotAvailable, rpcErrSlotSkipped, rpcErrLongTermStorageSlotSkipped,
+		rpcErrTransactionHistoryNotAvailable, rpcErrUnsupportedTransactionVersion,
+		rpcErrInvalidRequest, rpcErrMethodNotFound, rpcErrInvalidParams:
+		return false
+	}
+	return true
+}
+
+// RunHealthChecks polls the confirmed slot of every endpoint each interval until ctx is done,
+// so lagging endpoints are detected even when they are not being used, and logs the health
+// of each endpoint.
+func (c *RPCClients) RunHealthChecks(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.lock.Lock()
+		clients := c.clients
+		c.lock.Unlock()
+
+		var wg sync.WaitGroup
+		for idx, client := range clients {
+			wg.Add(1)
+			go func(idx int, client *rpc.Client) {
+				defer wg.Done()
+				checkCtx, cancel := context.WithTimeout(ctx, interval)
+				defer cancel()
+
+				start := time.Now()
+				slot, err := client.GetSlot(checkCtx, rpc.CommitmentConfirmed)
+				c.observe(idx, time.Since(start), err)
+				if err == nil {
+					c.observeSlot(idx, slot)
+				}
+			}(idx, client)
+		}
+		wg.Wait()
+
+		c.logHealth(logger)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunHealthLogs logs the health of every endpoint each interval until ctx is done, without
+// calling them. It is meant for endpoints only serving getBlock, paid archive nodes for example,
+// whose health comes from the outcome of those calls: they are never checked for slot lag.
+func (c *RPCClients) RunHealthLogs(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.logHealth(logger)
+		}
+	}
+}
+
+func (c *RPCClients) logHealth(logger *zap.Logger) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	endpoints := make([]zapcore.ObjectMarshaler, 0, len(c.health))
+	for _, h := range c.health {
+		lag := c.lagLocked(h)
+		benched := now.Before(h.benchedUntil)
+		endpoints = append(endpoints, endpointHealthLog{endpointHealth: *h, lag: lag, benched: benched})
+
+		RPCEndpointSlotLag.SetUint64(lag, c.pool, h.name)
+		if benched {
+			RPCEndpointBenched.SetInt(1, c.pool, h.name)
+		} else {
+			RPCEndpointBenched.SetInt(0, c.pool, h.name)
+		}
+	}
+
+	logger.Info("rpc endpoints health", zap.String("pool", c.pool), zap.Objects("endpoints", endpoints), zap.Uint64("max_slot", c.maxSlot))
+}
+
+// withClients calls f on each client in the order given by RPCClients.ordered until one
+// succeeds, recording each call's outcome in the endpoint's health. An *EndpointsError with
+// the errors of all the clients is returned when none succeeds.
+func withClients[V any](c *RPCClients, offset uint64, f func(client *rpc.Client) (V, error)) (v V, err error) {
+	order := c.ordered(offset)
+	if len(order) == 0 {
+		return v, fmt.Errorf("%s: %w", c.pool, ErrNoClients)
+	}
+
+	endpointsErr := &EndpointsError{}
+	for _, idx := range order {
+		start := time.Now()
+		v, err := f(c.clients[idx])
+		c.observe(idx, time.Since(start), err)
+		if err != nil {
+			endpointsErr.Errors = append(endpointsErr.Errors, &EndpointError{Endpoint: c.health[idx].name, Err: err})
+			continue
+		}
+		return v, nil
+	}
+	return v, endpointsErr
+}
+
+// getSlot returns the slot at the given commitment from the first endpoint answering.
+func (c *RPCClients) getSlot(ctx context.Context, commitment rpc.CommitmentType) (uint64, error) {
+	order := c.ordered(0)
+	if len(order) == 0 {
+		return 0, fmt.Errorf("%s: %w", c.pool, ErrNoClients)
+	}
+
+	endpointsErr := &EndpointsError{}
+	for _, idx := range order {
+		start := time.Now()
+		slot, err := c.clients[idx].GetSlot(ctx, commitment)
+		c.observe(idx, time.Since(start), err)
+		if err != nil {
+			endpointsErr.Errors = append(endpointsErr.Errors, &EndpointError{Endpoint: c.health[idx].name, Err: err})
+			continue
+		}
+		if commitment == rpc.CommitmentConfirmed {
+			c.observeSlot(idx, slot)
+		}
+		return slot, nil
+	}
+	return 0, endpointsErr
+}
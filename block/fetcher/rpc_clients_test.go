@@ -0,0 +1,157 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestRPCClients(config HealthConfig, names ...string) *RPCClients {
+	clients := NewRPCClientsWithHealthConfig("test", config)
+	for _, name := range names {
+		clients.Add(name, rpc.New("http://"+name))
+	}
+	return clients
+}
+
+func Test_RPCClientsOrdered(t *testing.T) {
+	config := HealthConfig{MaxSlotLag: 10, MaxConsecutiveErrors: 2, BenchDuration: time.Minute}
+	transportErr := errors.New("connection refused")
+
+	cases := []struct {
+		name     string
+		setup    func(c *RPCClients)
+		offset   uint64
+		expected []int
+	}{
+		{
+			name: "by latency",
+			setup: func(c *RPCClients) {
+				c.observe(0, 30*time.Millisecond, nil)
+				c.observe(1, 10*time.Millisecond, nil)
+				c.observe(2, 20*time.Millisecond, nil)
+			},
+			expected: []int{1, 2, 0},
+		},
+		{
+			name: "rotated by offset",
+			setup: func(c *RPCClients) {
+				c.observe(0, 30*time.Millisecond, nil)
+				c.observe(1, 10*time.Millisecond, nil)
+				c.observe(2, 12*time.Millisecond, nil)
+			},
+			offset:   3,
+			expected: []int{2, 1, 0},
+		},
+		{
+			name: "slower endpoints not rotated",
+			setup: func(c *RPCClients) {
+				c.observe(0, 30*time.Millisecond, nil)
+				c.observe(1, 10*time.Millisecond, nil)
+				c.observe(2, 20*time.Millisecond, nil)
+			},
+			offset:   4,
+			expected: []int{1, 2, 0},
+		},
+		{
+			name: "benched after consecutive errors",
+			setup: func(c *RPCClients) {
+				c.observe(0, time.Millisecond, transportErr)
+				c.observe(0, time.Millisecond, transportErr)
+				c.observe(1, 10*time.Millisecond, nil)
+				c.observe(2, 12*time.Millisecond, nil)
+			},
+			offset:   1,
+			expected: []int{2, 1, 0},
+		},
+		{
+			name: "rpc errors are not failures",
+			setup: func(c *RPCClients) {
+				for i := 0; i < 5; i++ {
+					c.observe(0, time.Millisecond, &jsonrpc.RPCError{Code: -32009})
+				}
+				c.observe(1, 10*time.Millisecond, nil)
+				c.observe(2, 20*time.Millisecond, nil)
+			},
+			expected: []int{0, 1, 2},
+		},
+		{
+			name: "node errors are failures",
+			setup: func(c *RPCClients) {
+				c.observe(0, time.Millisecond, &jsonrpc.RPCError{Code: -32005, Message: "Node is unhealthy"})
+				c.observe(0, time.Millisecond, &jsonrpc.RPCError{Code: -32603, Message: "Internal error"})
+				c.observe(1, 10*time.Millisecond, nil)
+				c.observe(2, 20*time.Millisecond, nil)
+			},
+			expected: []int{1, 2, 0},
+		},
+		{
+			name: "lagging last",
+			setup: func(c *RPCClients) {
+				c.observe(0, time.Millisecond, nil)
+				c.observeSlot(0, 100)
+				c.observeSlot(1, 120)
+				c.observeSlot(2, 115)
+			},
+			expected: []int{1, 2, 0},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clients := newTestRPCClients(config, "a", "b", "c")
+			c.setup(clients)
+			require.Equal(t, c.expected, clients.ordered(c.offset))
+		})
+	}
+}
+
+func Test_WithClientsFallsBack(t *testing.T) {
+	clients := newTestRPCClients(DefaultHealthConfig, "a", "b")
+
+	var called []*rpc.Client
+	v, err := withClients(clients, 0, func(client *rpc.Client) (int, error) {
+		called = append(called, client)
+		if len(called) == 1 {
+			return 0, errors.New("boom")
+		}
+		return 42, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 42, v)
+	require.Len(t, called, 2)
+
+	_, err = withClients(newTestRPCClients(DefaultHealthConfig), 0, func(client *rpc.Client) (int, error) { return 0, nil })
+	require.True(t, errors.Is(err, ErrNoClients))
+}
+
+func Test_RPCClientsHealthChecks(t *testing.T) {
+	run := func(runHealth func(c *RPCClients, ctx context.Context)) int32 {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":100}`))
+		}))
+		defer server.Close()
+
+		clients := NewRPCClients("test")
+		clients.Add("a", rpc.New(server.URL))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		runHealth(clients, ctx)
+		return requests.Load()
+	}
+
+	require.True(t, run(func(c *RPCClients, ctx context.Context) { c.RunHealthChecks(ctx, 5*time.Millisecond, zap.NewNop()) }) > 1)
+	require.Equal(t, int32(0), run(func(c *RPCClients, ctx context.Context) { c.RunHealthLogs(ctx, 5*time.Millisecond, zap.NewNop()) }))
+}
@@ -0,0 +1,60 @@
+package fetcher
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"go.uber.org/zap"
+)
+
+// ConversionPolicy tells what to do with RPC values that can't be converted to protobuf, like
+// a variant introduced by a validator upgrade.
+type ConversionPolicy string
+
+const (
+	// ConversionPolicyFail fails the block conversion.
+	ConversionPolicyFail ConversionPolicy = "fail"
+	// ConversionPolicyUnknown keeps the block, the value being replaced by an unknown marker and
+	// kept as received in the block's unknown values.
+	ConversionPolicyUnknown ConversionPolicy = "unknown"
+)
+
+func ParseConversionPolicy(in string) (ConversionPolicy, error) {
+	switch policy := ConversionPolicy(in); policy {
+	case ConversionPolicyFail, ConversionPolicyUnknown:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid conversion policy %q, valid values are %q and %q", in, ConversionPolicyFail, ConversionPolicyUnknown)
+	}
+}
+
+// rpcConverter converts a block returned by the RPC, collecting the values it could not
+// convert under ConversionPolicyUnknown.
+type rpcConverter struct {
+	policy  ConversionPolicy
+	unknown []*pbsol.UnknownValue
+	logger  *zap.Logger
+}
+
+// unknownValue handles value, found at path, that could not be converted because of err. It
+// returns err with ConversionPolicyFail, otherwise it records value and returns nil, the caller
+// then using the field's unknown marker.
+func (c *rpcConverter) unknownValue(path string, value any, err error) error {
+	if c.policy != ConversionPolicyUnknown {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	rawJSON, jsonErr := json.Marshal(value)
+	if jsonErr != nil {
+		return fmt.Errorf("%s: %w, keeping raw value: %s", path, err, jsonErr)
+	}
+
+	c.logger.Warn("keeping value that could not be converted", zap.String("path", path), zap.Error(err))
+	c.unknown = append(c.unknown, &pbsol.UnknownValue{
+		Path:    path,
+		Reason:  err.Error(),
+		RawJson: string(rawJSON),
+	})
+	return nil
+}
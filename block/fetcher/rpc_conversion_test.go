@@ -0,0 +1,185 @@
+package fetcher
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	bin "github.com/streamingfast/binary"
+	"github.com/streamingfast/firehose-solana/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+func testTransactionBase64(t *testing.T) string {
+	trx := &solana.Transaction{
+		Signatures: []solana.Signature{{1}},
+		Message: solana.Message{
+			Header:          solana.MessageHeader{NumRequiredSignatures: 1},
+			AccountKeys:     []solana.PublicKey{{2}},
+			RecentBlockhash: solana.Hash{3},
+			Instructions:    []solana.CompiledInstruction{{ProgramIDIndex: 0, Accounts: []uint16{0}, Data: []byte{4}}},
+		},
+	}
+	data, err := trx.MarshalBinary()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// newUnconvertibleBlockResult returns a block with an unknown reward type, a transaction with an
+// unknown error and an undecodable transaction.
+func newUnconvertibleBlockResult(t *testing.T) *rpc.GetBlockResult {
+	result := &rpc.GetBlockResult{}
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{
+		"blockhash": "11111111111111111111111111111111",
+		"previousBlockhash": "11111111111111111111111111111111",
+		"parentSlot": 9,
+		"transactions": [
+			{"transaction": [%q, "base64"], "meta": {"err": {"NewFancyError": [1]}, "fee": 5000}},
+			{"transaction": ["AAAA", "base64"], "meta": {"err": null, "fee": 1}}
+		],
+		"rewards": [
+			{"pubkey": "11111111111111111111111111111111", "lamports": 10, "postBalance": 10, "rewardType": "Fee"},
+			{"pubkey": "11111111111111111111111111111111", "lamports": 5, "postBalance": 5, "rewardType": "Inflation"}
+		]
+	}`, testTransactionBase64(t))), result)
+	require.NoError(t, err)
+	return result
+}
+
+func Test_BlockFromBlockResultFailPolicy(t *testing.T) {
+	_, err := blockFromBlockResult(10, 10, newUnconvertibleBlockResult(t), ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "transactions[1].transaction: ")
+}
+
+func Test_BlockFromBlockResultUnknownPolicy(t *testing.T) {
+	bstreamBlock, err := blockFromBlockResult(10, 10, newUnconvertibleBlockResult(t), ConversionPolicyUnknown, &patches.Registry{}, zap.NewNop())
+	require.NoError(t, err)
+
+	block := &pbsol.Block{}
+	require.NoError(t, bstreamBlock.Payload.UnmarshalTo(block))
+
+	require.Len(t, block.UnknownValues, 2)
+	require.Equal(t, "transactions[1].transaction", block.UnknownValues[0].Path)
+	require.Equal(t, `["AAAA","base64"]`, block.UnknownValues[0].RawJson)
+	require.Equal(t, "rewards[1].reward_type", block.UnknownValues[1].Path)
+	require.Equal(t, `"Inflation"`, block.UnknownValues[1].RawJson)
+	require.Equal(t, "unsupported reward type \"Inflation\"", block.UnknownValues[1].Reason)
+
+	require.Len(t, block.Transactions, 2)
+	trxErr, err := DecodeTransactionError(bin.NewDecoder(block.Transactions[0].Meta.Err.Err))
+	require.NoError(t, err)
+	require.Equal(t, &TransactionError{TrxErrCode: TrxErr_Unknown, detail: UnknownError{JSON: `{"NewFancyError":[1]}`}}, trxErr)
+	require.Equal(t, uint64(5000), block.Transactions[0].Meta.Fee)
+	require.Len(t, block.Transactions[0].Transaction.Signatures, 1)
+	require.Equal(t, &pbsol.Transaction{}, block.Transactions[1].Transaction, "undecodable transaction marker")
+	require.Equal(t, uint64(1), block.Transactions[1].Meta.Fee)
+
+	require.Len(t, block.Rewards, 2)
+	require.Equal(t, pbsol.RewardType_Unspecified, block.Rewards[0].RewardType, "rewards are sorted by lamports")
+	require.Equal(t, pbsol.RewardType_Fee, block.Rewards[1].RewardType)
+}
+
+func Test_ToPbInstructionsAccountIndexes(t *testing.T) {
+	instructions := []solana.CompiledInstruction{
+		{ProgramIDIndex: 0, Accounts: []uint16{1, 2}},
+		{ProgramIDIndex: 0, Accounts: []uint16{1, 300}},
+	}
+
+	_, err := (&rpcConverter{policy: ConversionPolicyFail, logger: zap.NewNop()}).toPbInstructions("instructions", instructions)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "instructions[1].accounts: account index 300 is greater than 255")
+
+	converter := &rpcConverter{policy: ConversionPolicyUnknown, logger: zap.NewNop()}
+	out, err := converter.toPbInstructions("instructions", instructions)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2}, out[0].Accounts)
+	require.Nil(t, out[1].Accounts)
+	require.Len(t, converter.unknown, 1)
+	require.Equal(t, "[1,300]", converter.unknown[0].RawJson)
+}
+
+func Test_NewTransactionError(t *testing.T) {
+	cases := []struct {
+		json     string
+		expected *TransactionError
+	}{
+		{json: `"AccountInUse"`, expected: &TransactionError{TrxErrCode: TrxErr_AccountInUse}},
+		{json: `{"DuplicateInstruction": 3}`, expected: &TransactionError{TrxErrCode: TrxErr_DuplicateInstruction, detail: &DuplicateInstructionError{duplicateInstructionIndex: 3}}},
+		{json: `{"InstructionError": [2, {"Custom": 6001}]}`, expected: &TransactionError{TrxErrCode: TrxErr_InstructionError, detail: &InstructionError{InstructionErrorCode: InstructionError_Custom, InstructionIndex: 2, detail: InstructionCustomError{CustomErrorCode: 6001}}}},
+
+		// Unknown variants are kept as received
+		{json: `"NewFancyError"`, expected: &TransactionError{TrxErrCode: TrxErr_Unknown, detail: UnknownError{JSON: `"NewFancyError"`}}},
+		{json: `{"NewFancyError": {"b": 1, "a": [2]}}`, expected: &TransactionError{TrxErrCode: TrxErr_Unknown, detail: UnknownError{JSON: `{"NewFancyError":{"a":[2],"b":1}}`}}},
+		{json: `{"InstructionError": [2, "NewFancyError"]}`, expected: &TransactionError{TrxErrCode: TrxErr_InstructionError, detail: &InstructionError{InstructionErrorCode: InstructionError_Unknown, InstructionIndex: 2, detail: UnknownError{JSON: `"NewFancyError"`}}}},
+		{json: `{"InstructionError": [2, {"Custom": -1}]}`, expected: &TransactionError{TrxErrCode: TrxErr_InstructionError, detail: &InstructionError{InstructionErrorCode: InstructionError_Unknown, InstructionIndex: 2, detail: UnknownError{JSON: `{"Custom":-1}`}}}},
+		{json: `{"InstructionError": null}`, expected: &TransactionError{TrxErrCode: TrxErr_Unknown, detail: UnknownError{JSON: `{"InstructionError":null}`}}},
+		{json: `{"InsufficientFundsForRent": {"account_index": 256}}`, expected: &TransactionError{TrxErrCode: TrxErr_Unknown, detail: UnknownError{JSON: `{"InsufficientFundsForRent":{"account_index":256}}`}}},
+		{json: `{"AccountInUse": 1}`, expected: &TransactionError{TrxErrCode: TrxErr_Unknown, detail: UnknownError{JSON: `{"AccountInUse":1}`}}},
+		{json: `12`, expected: &TransactionError{TrxErrCode: TrxErr_Unknown, detail: UnknownError{JSON: `12`}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.json, func(t *testing.T) {
+			var e any
+			require.NoError(t, json.Unmarshal([]byte(c.json), &e))
+
+			trxErr, err := NewTransactionError(e)
+			require.NoError(t, err)
+			require.Equal(t, c.expected, trxErr)
+
+			buf := bytes.NewBuffer(nil)
+			require.NoError(t, trxErr.Encode(bin.NewEncoder(buf)))
+			decoded, err := DecodeTransactionError(bin.NewDecoder(buf.Bytes()))
+			require.NoError(t, err)
+			require.Equal(t, c.expected, decoded)
+		})
+	}
+}
+
+func Test_ToPbTransactionMetaNone(t *testing.T) {
+	cases := []struct {
+		name     string
+		meta     string
+		expected *pbsol.TransactionStatusMeta
+	}{
+		{
+			name:     "not recorded",
+			meta:     `{"err": null, "fee": 1, "innerInstructions": null}`,
+			expected: &pbsol.TransactionStatusMeta{Fee: 1, InnerInstructionsNone: true, LogMessagesNone: true, ReturnDataNone: true},
+		},
+		{
+			name:     "recorded empty",
+			meta:     `{"err": null, "fee": 1, "innerInstructions": [], "logMessages": []}`,
+			expected: &pbsol.TransactionStatusMeta{Fee: 1, ReturnDataNone: true},
+		},
+		{
+			name: "recorded",
+			meta: `{"err": null, "fee": 1, "innerInstructions": [], "logMessages": ["Program log: hi"], "returnData": {"programId": "11111111111111111111111111111111", "data": ["AQI=", "base64"]}}`,
+			expected: &pbsol.TransactionStatusMeta{
+				Fee:         1,
+				LogMessages: []string{"Program log: hi"},
+				ReturnData:  &pbsol.ReturnData{ProgramId: make([]byte, 32), Data: []byte{1, 2}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			meta := &rpc.TransactionMeta{}
+			require.NoError(t, json.Unmarshal([]byte(c.meta), meta))
+
+			converter := &rpcConverter{policy: ConversionPolicyFail, logger: zap.NewNop()}
+			actual, err := converter.toPbTransactionMeta("meta", meta)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(c.expected, actual), "expected %s, got %s", c.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package fetcher
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"go.uber.org/zap"
+)
+
+// forkTrackerRetention is the number of slots below the finalized one for which the outcome of
+// an emitted block is kept, answering the poller asking for it again while resolving a fork.
+const forkTrackerRetention = 1000
+
+type forkStatus int
+
+const (
+	// forkPending blocks were emitted above the finalized slot and are not checked yet
+	forkPending forkStatus = iota
+	// forkFinalized blocks are part of the finalized chain with the blockhash they were emitted with
+	forkFinalized
+	// forkOrphaned slots are not part of the finalized chain, the cluster finalized another fork.
+	// They are only reported: the poller follows the finalized fork through the parents of the
+	// blocks it is given, never asking again for a slot that fork skipped.
+	forkOrphaned
+	// forkReplaced slots were finalized with another blockhash than the one emitted
+	forkReplaced
+)
+
+func (s forkStatus) String() string {
+	switch s {
+	case forkPending:
+		return "pending"
+	case forkFinalized:
+		return "finalized"
+	case forkOrphaned:
+		return "orphaned"
+	case forkReplaced:
+		return "replaced"
+	}
+	return "unknown"
+}
+
+type emittedBlock struct {
+	blockhash         string
+	previousBlockhash string
+	status            forkStatus
+}
+
+// forkTracker remembers the blocks emitted before being finalized, a confirmed block being
+// dropped when the cluster finalizes another fork, until they are checked against the
+// finalized chain.
+type forkTracker struct {
+	lock    sync.Mutex
+	emitted map[uint64]*emittedBlock
+
+	// checkLock ensures a single check runs at a time, concurrent fetches not waiting for it
+	checkLock sync.Mutex
+}
+
+func newForkTracker() *forkTracker {
+	return &forkTracker{emitted: map[uint64]*emittedBlock{}}
+}
+
+// emit records the block emitted for slot, replacing a previous one, status being forkPending
+// for a block above the finalized slot and forkFinalized for one fetched at finalized commitment.
+// previousBlockhash is the one returned by the endpoint, before chain patches are applied.
+func (t *forkTracker) emit(slot uint64, blockhash string, previousBlockhash string, status forkStatus) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.emitted[slot] = &emittedBlock{blockhash: blockhash, previousBlockhash: previousBlockhash, status: status}
+}
+
+// status returns the status of the block emitted for slot, found being false if none was.
+func (t *forkTracker) status(slot uint64) (status forkStatus, found bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if block, found := t.emitted[slot]; found {
+		return block.status, true
+	}
+	return 0, false
+}
+
+// pending returns the slots, in order, of the blocks still pending up to finalizedSlot, along
+// with the blocks emitted for them, and prunes the outcomes older than forkTrackerRetention.
+func (t *forkTracker) pending(finalizedSlot uint64) (slots []uint64, blocks map[uint64]emittedBlock) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	blocks = map[uint64]emittedBlock{}
+	for slot, block := range t.emitted {
+		if block.status != forkPending {
+			if slot+forkTrackerRetention < finalizedSlot {
+				delete(t.emitted, slot)
+			}
+			continue
+		}
+		if slot <= finalizedSlot {
+			slots = append(slots, slot)
+			blocks[slot] = *block
+		}
+	}
+	slices.Sort(slots)
+	return slots, blocks
+}
+
+func (t *forkTracker) resolve(slot uint64, blockhash string, status forkStatus) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// The slot could have been emitted again in the meantime, that block is checked later
+	if block, found := t.emitted[slot]; found && block.blockhash == blockhash {
+		block.status = status
+	}
+}
+
+// checkForks verifies the blocks emitted before being finalized whose slot is now finalized,
+// each of them being either part of the finalized chain, orphaned or replaced by another block
+// for the same slot. Failures are logged and the check is retried on a later call.
+//
+// The finalized slots come from getBlocks, the slots missing from it being orphaned. Their
+// blockhashes are then found walking the finalized chain down from the block following the last
+// pending slot, each block's previous blockhash being the blockhash of the finalized slot
+// preceding it. An emitted block matching it carries the next previous blockhash, a single
+// finalized block is fetched as long as the emitted blocks link to the finalized chain.
+func (f *RPCFetcher) checkForks(ctx context.Context, latestFinalizedSlot uint64) {
+	if !f.forks.checkLock.TryLock() {
+		return
+	}
+	defer f.forks.checkLock.Unlock()
+
+	slots, emitted := f.forks.pending(latestFinalizedSlot)
+	if len(slots) == 0 {
+		return
+	}
+
+	first, last := slots[0], slots[len(slots)-1]
+	finalizedSlots, err := withClients(f.blockClients, first, func(client *rpc.Client) (rpc.BlocksResult, error) {
+		return client.GetBlocks(ctx, first, &latestFinalizedSlot, rpc.CommitmentFinalized)
+	})
+	if err != nil {
+		f.logger.Warn("unable to check emitted blocks against the finalized chain, will retry", zap.Uint64("first_slot", first), zap.Uint64("last_slot", last), zap.Error(err))
+		return
+	}
+
+	// lowest is the lowest pending slot part of the finalized chain, the walk stops there
+	lowest, found := uint64(0), false
+	for _, slot := range slots {
+		if !slices.Contains(finalizedSlots, slot) {
+			f.reportFork(slot, emitted[slot].blockhash, "", forkOrphaned)
+			continue
+		}
+		if !found {
+			lowest, found = slot, true
+		}
+	}
+	if !found {
+		return
+	}
+
+	// The walk starts at the finalized block following the last pending slot, or the highest
+	// finalized slot when none follows it yet
+	start := len(finalizedSlots) - 1
+	if i := slices.IndexFunc(finalizedSlots, func(slot uint64) bool { return slot > last }); i != -1 {
+		start = i
+	}
+
+	var blockhash, previousBlockhash string
+	for i := start; i >= 0 && finalizedSlots[i] >= lowest; i-- {
+		slot := finalizedSlots[i]
+
+		if i == start {
+			blockhash, previousBlockhash, err = f.finalizedBlockhashes(ctx, slot)
+			if err != nil {
+				f.logger.Warn("unable to get finalized blockhash of emitted block, will retry", zap.Uint64("block_num", slot), zap.Error(err))
+				return
+			}
+		} else {
+			blockhash, previousBlockhash = previousBlockhash, ""
+		}
+
+		if block, pending := emitted[slot]; pending {
+			if block.blockhash == blockhash {
+				f.forks.resolve(slot, block.blockhash, forkFinalized)
+				previousBlockhash = block.previousBlockhash
+				continue
+			}
+			f.reportFork(slot, block.blockhash, blockhash, forkReplaced)
+		}
+
+		if slot == lowest || previousBlockhash != "" {
+			continue
+		}
+
+		// The emitted blocks no longer link to the finalized chain, it is fetched to go on
+		_, previousBlockhash, err = f.finalizedBlockhashes(ctx, slot)
+		if err != nil {
+			f.logger.Warn("unable to get finalized blockhash of emitted block, will retry", zap.Uint64("block_num", slot), zap.Error(err))
+			return
+		}
+	}
+}
+
+// finalizedBlockhashes gets the blockhash and previous blockhash of the finalized block of slot,
+// without its transactions nor rewards.
+func (f *RPCFetcher) finalizedBlockhashes(ctx context.Context, slot uint64) (blockhash string, previousBlockhash string, err error) {
+	withRewards := false
+	maxSupportedTransactionVersion := f.requestConfig.MaxSupportedTransactionVersion
+	result, err := withClients(f.blockClients, slot, func(client *rpc.Client) (*rpc.GetBlockResult, error) {
+		return client.GetBlockWithOpts(ctx, slot, &rpc.GetBlockOpts{
+			TransactionDetails:             rpc.TransactionDetailsNone,
+			Rewards:                        &withRewards,
+			Commitment:                     rpc.CommitmentFinalized,
+			MaxSupportedTransactionVersion: &maxSupportedTransactionVersion,
+		})
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return result.Blockhash.String(), result.PreviousBlockhash.String(), nil
+}
+
+func (f *RPCFetcher) reportFork(slot uint64, emittedBlockhash string, finalizedBlockhash string, status forkStatus) {
+	f.logger.Warn("emitted block is not part of the finalized chain",
+		zap.Uint64("block_num", slot),
+		zap.String("emitted_block_hash", emittedBlockhash),
+		zap.String("finalized_block_hash", finalizedBlockhash),
+		zap.Stringer("status", status),
+	)
+	RPCForkedBlockCount.Inc(status.String())
+	f.forks.resolve(slot, emittedBlockhash, status)
+}
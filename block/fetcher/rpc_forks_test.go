@@ -0,0 +1,321 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/firehose-core/blockpoller"
+	"github.com/streamingfast/firehose-solana/patches"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeChain is an RPC server serving a confirmed and a finalized view of the chain, each view
+// mapping slots to the name of their block, the test forking the chain by changing them.
+type fakeChain struct {
+	sync.Mutex
+	confirmedSlot   uint64
+	finalizedSlot   uint64
+	confirmed       map[uint64]string
+	finalized       map[uint64]string
+	getBlockCommits []string
+}
+
+func newFakeChain(t *testing.T, chain *fakeChain) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		opts := req.Params[len(req.Params)-1].(map[string]any)
+
+		chain.Lock()
+		defer chain.Unlock()
+
+		view := chain.confirmed
+		if opts["commitment"] == "finalized" {
+			view = chain.finalized
+		}
+
+		var response string
+		switch req.Method {
+		case "getSlot":
+			slot := chain.confirmedSlot
+			if opts["commitment"] == "finalized" {
+				slot = chain.finalizedSlot
+			}
+			response = fmt.Sprintf(`"result":%d`, slot)
+		case "getBlocks":
+			var slots []uint64
+			for slot := range view {
+				if slot >= uint64(req.Params[0].(float64)) && slot <= uint64(req.Params[1].(float64)) {
+					slots = append(slots, slot)
+				}
+			}
+			sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+			out, _ := json.Marshal(slots)
+			response = fmt.Sprintf(`"result":%s`, out)
+		case "getBlock":
+			slot := uint64(req.Params[0].(float64))
+			chain.getBlockCommits = append(chain.getBlockCommits, opts["commitment"].(string))
+			name, found := view[slot]
+			if !found {
+				response = fmt.Sprintf(`"error":{"code":-32007,"message":"Slot %d was skipped, or missing due to ledger jump to recent snapshot"}`, slot)
+				break
+			}
+			parentSlot := slot - 1
+			for ; parentSlot > 0 && view[parentSlot] == ""; parentSlot-- {
+			}
+			response = fmt.Sprintf(`"result":{"blockhash":%q,"previousBlockhash":%q,"parentSlot":%d,"transactions":[],"rewards":[]}`, fakeBlockhash(name), fakeBlockhash(view[parentSlot]), parentSlot)
+		default:
+			t.Errorf("unexpected method %q", req.Method)
+		}
+
+		id, _ := json.Marshal(req.ID)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,%s}`, id, response)
+	}))
+}
+
+func fakeBlockhash(name string) string {
+	hash := sha256.Sum256([]byte(name))
+	return solana.HashFromBytes(hash[:]).String()
+}
+
+func Test_RPCFetcherForks(t *testing.T) {
+	cases := []struct {
+		name string
+		// finalized is the finalized view once slots 9 to 12 are finalized
+		finalized      map[uint64]string
+		expectedStatus forkStatus
+		// expectedBlock is the block returned when the poller asks for slot 10 again, none
+		// for a skipped slot
+		expectedBlock string
+		// expectedCheckFetches is the number of finalized blocks fetched by the check, one
+		// more than the finalized successor each time the emitted blocks stop linking to it
+		expectedCheckFetches int
+	}{
+		{
+			name:                 "finalized",
+			finalized:            map[uint64]string{9: "9", 10: "10", 11: "11", 12: "12"},
+			expectedStatus:       forkFinalized,
+			expectedBlock:        "10",
+			expectedCheckFetches: 1,
+		},
+		{
+			name:                 "orphaned",
+			finalized:            map[uint64]string{9: "9", 11: "11'", 12: "12'"},
+			expectedStatus:       forkOrphaned,
+			expectedCheckFetches: 1,
+		},
+		{
+			name:                 "replaced",
+			finalized:            map[uint64]string{9: "9", 10: "10'", 11: "11'", 12: "12'"},
+			expectedStatus:       forkReplaced,
+			expectedBlock:        "10'",
+			expectedCheckFetches: 2,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			chain := &fakeChain{
+				confirmedSlot: 11,
+				finalizedSlot: 9,
+				confirmed:     map[uint64]string{9: "9", 10: "10", 11: "11"},
+				finalized:     map[uint64]string{9: "9"},
+			}
+			server := newFakeChain(t, chain)
+			defer server.Close()
+
+			clients := NewRPCClients("test")
+			clients.Add("a", rpc.New(server.URL))
+			f := NewRPC(clients, clients, 0, time.Millisecond, 1, DefaultRetryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+
+			ctx := context.Background()
+			block, _, err := f.Fetch(ctx, 10)
+			require.NoError(t, err)
+			require.Equal(t, fakeBlockhash("10"), block.Id)
+
+			status, emitted := f.forks.status(10)
+			require.True(t, emitted)
+			require.Equal(t, forkPending, status)
+
+			_, _, err = f.Fetch(ctx, 11)
+			require.NoError(t, err)
+
+			// The cluster finalizes up to slot 12, the confirmed view following it
+			chain.Lock()
+			chain.confirmedSlot, chain.finalizedSlot = 12, 12
+			chain.confirmed, chain.finalized = c.finalized, c.finalized
+			chain.getBlockCommits = nil
+			chain.Unlock()
+
+			block, _, err = f.Fetch(ctx, 12)
+			require.NoError(t, err)
+			require.Equal(t, fakeBlockhash(c.finalized[12]), block.Id)
+
+			status, _ = f.forks.status(10)
+			require.Equal(t, c.expectedStatus, status)
+
+			chain.Lock()
+			require.Equal(t, c.expectedCheckFetches, countOf(chain.getBlockCommits, "finalized"), "getBlock commitments: %v", chain.getBlockCommits)
+			chain.Unlock()
+
+			// Asked again for slot 10, it is fetched at finalized commitment, the finalized chain
+			// skipping it when it was orphaned
+			block, skip, err := f.Fetch(ctx, 10)
+			require.NoError(t, err)
+			if c.expectedBlock == "" {
+				require.True(t, skip)
+				return
+			}
+			require.False(t, skip)
+			require.Equal(t, fakeBlockhash(c.expectedBlock), block.Id)
+
+			chain.Lock()
+			defer chain.Unlock()
+			require.Equal(t, "finalized", chain.getBlockCommits[len(chain.getBlockCommits)-1])
+		})
+	}
+}
+
+// firedBlocks is a poller block handler sending the blocks fired to a channel, stopping the
+// poller once it fires stopAt.
+type firedBlocks struct {
+	fired  chan *pbbstream.Block
+	stopAt uint64
+}
+
+var errStopPoller = errors.New("stopping poller")
+
+func (h *firedBlocks) Init() {}
+
+func (h *firedBlocks) Handle(block *pbbstream.Block) error {
+	h.fired <- block
+	if block.Number == h.stopAt {
+		return errStopPoller
+	}
+	return nil
+}
+
+func (h *firedBlocks) next(t *testing.T, count int) (ids []string) {
+	for i := 0; i < count; i++ {
+		select {
+		case block := <-h.fired:
+			ids = append(ids, block.Id)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("poller fired %d blocks out of %d", i, count)
+		}
+	}
+	return ids
+}
+
+// Test_RPCFetcherForksPoller follows a fork through the poller: when the cluster finalizes
+// another fork than the one emitted, the poller asks again for the parents of the first block
+// of that fork, by their blockhash, until it links to a block it fired.
+func Test_RPCFetcherForksPoller(t *testing.T) {
+	cases := []struct {
+		name      string
+		finalized map[uint64]string
+		// expectedFired are the blocks fired once slots 9 to 12 are finalized
+		expectedFired []string
+		// expectedStatus is the status of slot 10 once the poller stops, a replaced block
+		// being finalized once fetched again
+		expectedStatus forkStatus
+	}{
+		{
+			name:           "finalized",
+			finalized:      map[uint64]string{9: "9", 10: "10", 11: "11", 12: "12"},
+			expectedFired:  []string{"12"},
+			expectedStatus: forkFinalized,
+		},
+		{
+			// Slot 10 is not asked for again, its orphaned block is not on the path from the
+			// finalized fork to the blocks fired
+			name:           "orphaned",
+			finalized:      map[uint64]string{9: "9", 11: "11'", 12: "12'"},
+			expectedFired:  []string{"11'", "12'"},
+			expectedStatus: forkOrphaned,
+		},
+		{
+			name:           "replaced",
+			finalized:      map[uint64]string{9: "9", 10: "10'", 11: "11'", 12: "12'"},
+			expectedFired:  []string{"10'", "11'", "12'"},
+			expectedStatus: forkFinalized,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			chain := &fakeChain{
+				confirmedSlot: 11,
+				finalizedSlot: 9,
+				confirmed:     map[uint64]string{9: "9", 10: "10", 11: "11"},
+				finalized:     map[uint64]string{9: "9"},
+			}
+			server := newFakeChain(t, chain)
+			defer server.Close()
+
+			clients := NewRPCClients("test")
+			clients.Add("a", rpc.New(server.URL))
+			f := NewRPC(clients, clients, 0, time.Millisecond, 1, DefaultRetryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+			defer f.Close()
+
+			handler := &firedBlocks{fired: make(chan *pbbstream.Block, 10), stopAt: 12}
+			poller := blockpoller.New(f, handler, blockpoller.WithLogger(zap.NewNop()))
+
+			done := make(chan error, 1)
+			go func() { done <- RunPoller(context.Background(), poller, 9, 1) }()
+
+			require.Equal(t, names("9", "10", "11"), handler.next(t, 3))
+
+			// The cluster finalizes up to slot 12 while the poller waits for it
+			chain.Lock()
+			chain.confirmedSlot, chain.finalizedSlot = 12, 12
+			chain.confirmed, chain.finalized = c.finalized, c.finalized
+			chain.Unlock()
+
+			require.Equal(t, names(c.expectedFired...), handler.next(t, len(c.expectedFired)))
+
+			select {
+			case err := <-done:
+				require.True(t, errors.Is(err, errStopPoller), "unexpected poller error: %v", err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("poller should stop once slot 12 is fired")
+			}
+
+			status, _ := f.forks.status(10)
+			require.Equal(t, c.expectedStatus, status)
+		})
+	}
+}
+
+func names(blocks ...string) (hashes []string) {
+	for _, block := range blocks {
+		hashes = append(hashes, fakeBlockhash(block))
+	}
+	return hashes
+}
+
+func countOf(values []string, value string) (count int) {
+	for _, v := range values {
+		if v == value {
+			count++
+		}
+	}
+	return count
+}
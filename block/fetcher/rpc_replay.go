@@ -0,0 +1,199 @@
+package fetcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// RPCExchange is a JSON-RPC request and the response an endpoint answered to it.
+type RPCExchange struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	// Response is the endpoint's response without its jsonrpc and id members, holding either
+	// a result or an error member.
+	Response json.RawMessage `json:"response"`
+}
+
+// RPCRecording holds the exchanges recorded with an endpoint, in the order they were made.
+type RPCRecording struct {
+	Exchanges []*RPCExchange `json:"exchanges"`
+}
+
+func LoadRPCRecording(path string) (*RPCRecording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading recording: %w", err)
+	}
+
+	recording := &RPCRecording{}
+	if err := json.Unmarshal(data, recording); err != nil {
+		return nil, fmt.Errorf("decoding recording %s: %w", path, err)
+	}
+	return recording, nil
+}
+
+func (r *RPCRecording) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding recording: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// rpcRequest is the part of a JSON-RPC request identifying the exchange.
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// key identifies the exchanges of a request, params being compacted so their formatting does
+// not matter.
+func (r *rpcRequest) key() string {
+	params := &bytes.Buffer{}
+	if err := json.Compact(params, r.Params); err != nil {
+		params.Write(r.Params)
+	}
+	return r.Method + params.String()
+}
+
+// RPCRecorder is an http.RoundTripper recording the JSON-RPC exchanges made through it, to be
+// used as the transport of the rpc.Client under recording.
+type RPCRecorder struct {
+	transport http.RoundTripper
+
+	lock      sync.Mutex
+	recording *RPCRecording
+}
+
+// NewRPCRecorder creates a recorder sending requests through transport,
+// http.DefaultTransport when nil.
+func NewRPCRecorder(transport http.RoundTripper) *RPCRecorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &RPCRecorder{transport: transport, recording: &RPCRecording{}}
+}
+
+func (r *RPCRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading request: %w", err)
+	}
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	// Only JSON-RPC answers are recorded, transport failures being the endpoint's own
+	var request rpcRequest
+	var response map[string]json.RawMessage
+	if json.Unmarshal(body, &request) != nil || json.Unmarshal(respBody, &response) != nil {
+		return resp, nil
+	}
+	delete(response, "jsonrpc")
+	delete(response, "id")
+
+	exchange := &RPCExchange{Method: request.Method, Params: request.Params}
+	if exchange.Response, err = json.Marshal(response); err != nil {
+		return nil, fmt.Errorf("encoding response: %w", err)
+	}
+
+	r.lock.Lock()
+	r.recording.Exchanges = append(r.recording.Exchanges, exchange)
+	r.lock.Unlock()
+
+	return resp, nil
+}
+
+// Recording returns the exchanges recorded so far.
+func (r *RPCRecorder) Recording() *RPCRecording {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return &RPCRecording{Exchanges: append([]*RPCExchange{}, r.recording.Exchanges...)}
+}
+
+// RPCReplayer is an http.Handler answering JSON-RPC requests with the responses of a recording,
+// standing in for the recorded endpoint. Requests are matched on their method and params, the
+// responses recorded for the same request being replayed in order, the last one repeating. A
+// request without recorded response is answered with an invalid request error.
+type RPCReplayer struct {
+	lock      sync.Mutex
+	responses map[string][]json.RawMessage
+	served    map[string]int
+	// methods counts the requests answered from the recording by method
+	methods map[string]int
+}
+
+func NewRPCReplayer(recording *RPCRecording) *RPCReplayer {
+	r := &RPCReplayer{responses: map[string][]json.RawMessage{}, served: map[string]int{}, methods: map[string]int{}}
+	for _, exchange := range recording.Exchanges {
+		key := (&rpcRequest{Method: exchange.Method, Params: exchange.Params}).key()
+		r.responses[key] = append(r.responses[key], exchange.Response)
+	}
+	return r
+}
+
+func (r *RPCReplayer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var request rpcRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("decoding request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	response, found := r.next(request.Method, request.key())
+	if !found {
+		message, _ := json.Marshal(fmt.Sprintf("no recorded response for %s %s", request.Method, request.Params))
+		response = json.RawMessage(fmt.Sprintf(`{"error":{"code":%d,"message":%s}}`, rpcErrInvalidRequest, message))
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(response, &members); err != nil {
+		http.Error(w, fmt.Sprintf("decoding recorded response: %s", err), http.StatusInternalServerError)
+		return
+	}
+	members["jsonrpc"] = json.RawMessage(`"2.0"`)
+	members["id"] = request.ID
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(members)
+}
+
+func (r *RPCReplayer) next(method string, key string) (json.RawMessage, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	responses := r.responses[key]
+	if len(responses) == 0 {
+		return nil, false
+	}
+
+	served := r.served[key]
+	r.served[key]++
+	r.methods[method]++
+	return responses[min(served, len(responses)-1)], true
+}
+
+// Served returns how many times a request for method was answered from the recording.
+func (r *RPCReplayer) Served(method string) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.methods[method]
+}
@@ -0,0 +1,53 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/streamingfast/firehose-solana/patches"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+func Test_RPCRecorderRoundTrip(t *testing.T) {
+	recording, err := LoadRPCRecording("testdata/rpc/fetch.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(NewRPCReplayer(recording))
+	defer server.Close()
+
+	// Recording the replayed exchanges gives back the recording
+	recorder := NewRPCRecorder(nil)
+	clients := NewRPCClients("record")
+	clients.Add("a", rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(server.URL, &jsonrpc.RPCClientOpts{
+		HTTPClient: &http.Client{Transport: recorder},
+	})))
+	retryConfig := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	f := NewRPC(clients, clients, 0, time.Millisecond, 1, retryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+
+	for slot := uint64(100); slot <= 106; slot++ {
+		_, _, err := f.Fetch(context.Background(), slot)
+		require.NoError(t, err)
+	}
+
+	expected, err := json.Marshal(recording)
+	require.NoError(t, err)
+	actual, err := json.Marshal(recorder.Recording())
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(actual))
+}
+
+func Test_RPCReplayerUnrecorded(t *testing.T) {
+	server := httptest.NewServer(NewRPCReplayer(&RPCRecording{}))
+	defer server.Close()
+
+	_, err := rpc.New(server.URL).GetSlot(context.Background(), rpc.CommitmentConfirmed)
+	require.Error(t, err)
+	require.True(t, IsPermanentError(err), "unexpected error %v", err)
+}
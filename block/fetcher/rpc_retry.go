@@ -0,0 +1,165 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+// Solana JSON-RPC server error codes, see solana's rpc-client-api custom_error.rs
+const (
+	rpcErrBlockCleanedUp                 = -32001
+	rpcErrBlockNotAvailable              = -32004
+	rpcErrSlotSkipped                    = -32007
+	rpcErrLongTermStorageSlotSkipped     = -32009
+	rpcErrTransactionHistoryNotAvailable = -32011
+	rpcErrUnsupportedTransactionVersion  = -32015
+	rpcErrInvalidRequest                 = -32600
+	rpcErrMethodNotFound                 = -32601
+	rpcErrInvalidParams                  = -32602
+)
+
+type RetryConfig struct {
+	// MaxAttempts is the number of times all endpoints are tried before giving up on a slot,
+	// 0 retries forever.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts:    20,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+}
+
+// backoff returns the delay before the given attempt, attempt 1 being the first retry.
+func (c RetryConfig) backoff(attempt int) time.Duration {
+	delay := c.InitialBackoff
+	for i := 1; i < attempt && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, c.MaxBackoff)
+}
+
+func (c RetryConfig) exhausted(attempt int) bool {
+	return c.MaxAttempts > 0 && attempt >= c.MaxAttempts
+}
+
+// EndpointError is the error returned by a single endpoint.
+type EndpointError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *EndpointError) Error() string {
+	return fmt.Sprintf("endpoint %s: %s", e.Endpoint, e.Err)
+}
+
+func (e *EndpointError) Unwrap() error {
+	return e.Err
+}
+
+// EndpointsError holds the errors of every endpoint tried for a call, none of them succeeding.
+type EndpointsError struct {
+	Errors []*EndpointError
+}
+
+func (e *EndpointsError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *EndpointsError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Permanent tells if every endpoint failed with an error that retrying will not fix.
+func (e *EndpointsError) Permanent() bool {
+	for _, err := range e.Errors {
+		if !IsPermanentError(err.Err) {
+			return false
+		}
+	}
+	return len(e.Errors) > 0
+}
+
+// FetchError is returned once fetching a slot is given up, either because every endpoint
+// returned a permanent error or because the retry budget is exhausted.
+type FetchError struct {
+	Slot      uint64
+	Attempts  int
+	Permanent bool
+	// Last holds the errors of each endpoint on the last attempt
+	Last *EndpointsError
+}
+
+func (e *FetchError) Error() string {
+	kind := "retry budget exhausted"
+	if e.Permanent {
+		kind = "permanent error"
+	}
+	return fmt.Sprintf("giving up on slot %d after %d attempt(s), %s: %s", e.Slot, e.Attempts, kind, e.Last)
+}
+
+func (e *FetchError) Unwrap() error {
+	return e.Last
+}
+
+// IsPermanentError tells if err, returned by an endpoint, will not go away by retrying the
+// same request. Transport failures, timeouts, throttling, server errors and data not yet
+// available are transient, rejected requests, pruned history and undecodable answers are
+// permanent.
+func IsPermanentError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return false
+	}
+
+	var rpcErr *jsonrpc.RPCError
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.Code {
+		case rpcErrBlockCleanedUp, rpcErrTransactionHistoryNotAvailable, rpcErrUnsupportedTransactionVersion,
+			rpcErrInvalidRequest, rpcErrMethodNotFound, rpcErrInvalidParams:
+			return true
+		}
+		return false
+	}
+
+	var httpErr *jsonrpc.HTTPError
+	if errors.As(err, &httpErr) {
+		switch {
+		case httpErr.Code == http.StatusRequestTimeout, httpErr.Code == http.StatusTooManyRequests, httpErr.Code >= 500:
+			return false
+		}
+		return true
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		return false
+	}
+
+	// Anything else is a response that could not be decoded
+	return true
+}
@@ -0,0 +1,131 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/firehose-solana/patches"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+func Test_IsPermanentError(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       error
+		permanent bool
+	}{
+		{"block cleaned up", &jsonrpc.RPCError{Code: rpcErrBlockCleanedUp}, true},
+		{"invalid params", &jsonrpc.RPCError{Code: rpcErrInvalidParams}, true},
+		{"block not available", &jsonrpc.RPCError{Code: rpcErrBlockNotAvailable}, false},
+		{"node unhealthy", &jsonrpc.RPCError{Code: -32005}, false},
+		{"too many requests", &jsonrpc.HTTPError{Code: http.StatusTooManyRequests}, false},
+		{"bad gateway", &jsonrpc.HTTPError{Code: http.StatusBadGateway}, false},
+		{"unauthorized", &jsonrpc.HTTPError{Code: http.StatusUnauthorized}, true},
+		{"deadline", fmt.Errorf("rpc call: %w", context.DeadlineExceeded), false},
+		{"truncated body", fmt.Errorf("could not decode body: %w", io.ErrUnexpectedEOF), false},
+		{"undecodable result", errors.New("json: cannot unmarshal string into Go value of type uint64"), true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.permanent, IsPermanentError(c.err))
+		})
+	}
+}
+
+func Test_RetryConfigBackoff(t *testing.T) {
+	config := RetryConfig{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	require.Equal(t, 100*time.Millisecond, config.backoff(1))
+	require.Equal(t, 200*time.Millisecond, config.backoff(2))
+	require.Equal(t, 800*time.Millisecond, config.backoff(4))
+	require.Equal(t, time.Second, config.backoff(5))
+	require.Equal(t, time.Second, config.backoff(100))
+}
+
+// newRPCErrorServer answers getSlot with headSlot and getBlock with the given error code,
+// or with an HTTP 503 when code is 0.
+func newRPCErrorServer(t *testing.T, headSlot uint64, code int, getBlockCalls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		id, _ := json.Marshal(req.ID)
+
+		if req.Method == "getSlot" {
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%d}`, id, headSlot)
+			return
+		}
+
+		getBlockCalls.Add(1)
+		if code == 0 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":%d,"message":"failure"}}`, id, code)
+	}))
+}
+
+func Test_RPCFetcherRetries(t *testing.T) {
+	retryConfig := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	cases := []struct {
+		name             string
+		code             int
+		expectSkip       bool
+		expectPermanent  bool
+		expectAttempts   int
+		expectBlockCalls int32
+	}{
+		{name: "skipped", code: rpcErrSlotSkipped, expectSkip: true, expectBlockCalls: 2},
+		{name: "pruned history is permanent", code: rpcErrBlockCleanedUp, expectPermanent: true, expectAttempts: 1, expectBlockCalls: 2},
+		{name: "unavailable exhausts budget", code: 0, expectAttempts: 3, expectBlockCalls: 6},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := newRPCErrorServer(t, 100, c.code, &calls)
+			defer server.Close()
+
+			clients := NewRPCClients("test")
+			clients.Add("a", rpc.New(server.URL))
+			clients.Add("b", rpc.New(server.URL))
+			f := NewRPC(clients, clients, 0, time.Millisecond, 1, retryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+
+			_, skip, err := f.Fetch(context.Background(), 10)
+			require.Equal(t, c.expectBlockCalls, calls.Load())
+			if c.expectSkip {
+				require.NoError(t, err)
+				require.True(t, skip)
+				return
+			}
+
+			var fatalErr *derr.FatalError
+			require.True(t, errors.As(err, &fatalErr), "error should stop the poller retries")
+
+			var fetchErr *FetchError
+			require.True(t, errors.As(err, &fetchErr))
+			require.Equal(t, uint64(10), fetchErr.Slot)
+			require.Equal(t, c.expectPermanent, fetchErr.Permanent)
+			require.Equal(t, c.expectAttempts, fetchErr.Attempts)
+			require.Len(t, fetchErr.Last.Errors, 2)
+			require.Contains(t, err.Error(), "endpoint a:")
+			require.Contains(t, err.Error(), "endpoint b:")
+		})
+	}
+}
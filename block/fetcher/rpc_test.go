@@ -2,22 +2,448 @@ package fetcher
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gagliardetto/solana-go"
-
+	"github.com/gagliardetto/solana-go/rpc"
 	bin "github.com/streamingfast/binary"
+	"github.com/streamingfast/firehose-solana/patches"
 	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
 )
 
-func Test_DoIt(t *testing.T) {
-	t.Skip("Only for manual testing")
-	//ctx := context.Background()
-	//rpcClient := rpc.New(quicknodeURL) //put your own URL in a file call secret.go that will be ignore by git
-	//f := NewRPC(rpcClient, 0*time.Millisecond, 0*time.Millisecond, zap.NewNop())
-	//_, err := f.Fetch(ctx, 240816742)
-	//
-	//require.NoError(t, err)
+func Test_RPCFetcherReplay(t *testing.T) {
+	// A recording, as written by `firesol tools record-rpc`, of fetching slots 100 to 106
+	recording, err := LoadRPCRecording("testdata/rpc/fetch.json")
+	require.NoError(t, err)
+
+	replayer := NewRPCReplayer(recording)
+	server := httptest.NewServer(replayer)
+	defer server.Close()
+
+	chainPatches, err := patches.Parse([]byte(`version: 1
+skipped_slots:
+  - {first: 105, last: 105}
+previous_blockhashes:
+  - {blockhash: 5rkfzQHxfC4t7gw579iE66F6XQt4TK4MmYEg3azJeQVZ, previous_blockhash: HQEr9qcbUVBt2okfu755FdJvJrPYTSpzzmmyeWTj5oau}
+`))
+	require.NoError(t, err)
+
+	clients := NewRPCClients("replay")
+	clients.Add("a", rpc.New(server.URL))
+	retryConfig := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	f := NewRPC(clients, clients, 0, time.Millisecond, 1, retryConfig, DefaultRequestConfig, ConversionPolicyFail, chainPatches, zap.NewNop())
+
+	type expectedBlock struct {
+		skipped  bool
+		id       string
+		parentID string
+		libNum   uint64
+	}
+	expected := map[uint64]expectedBlock{
+		// Below the finalized slot, the LIB is the parent
+		100: {id: fakeBlockhash("100"), parentID: fakeBlockhash("99"), libNum: 99},
+		// Slot skipped error
+		101: {skipped: true},
+		// Long-term storage slot skipped error
+		102: {skipped: true},
+		103: {id: fakeBlockhash("103"), parentID: fakeBlockhash("100"), libNum: 103},
+		// Node behind error, retried
+		104: {id: fakeBlockhash("104"), parentID: fakeBlockhash("103"), libNum: 103},
+		// Skipped by chain patches
+		105: {skipped: true},
+		// Block not available at the head, retried, previous blockhash patched
+		106: {id: fakeBlockhash("106"), parentID: "HQEr9qcbUVBt2okfu755FdJvJrPYTSpzzmmyeWTj5oau", libNum: 103},
+	}
+
+	for slot := uint64(100); slot <= 106; slot++ {
+		block, skip, err := f.Fetch(context.Background(), slot)
+		require.NoError(t, err, "slot %d", slot)
+
+		if expected[slot].skipped {
+			require.True(t, skip, "slot %d should be skipped", slot)
+			continue
+		}
+		require.False(t, skip, "slot %d should not be skipped", slot)
+		require.Equal(t, expected[slot], expectedBlock{id: block.Id, parentID: block.ParentId, libNum: block.LibNum}, "slot %d", slot)
+	}
+
+	// Slot 105 is never asked for, slots 104 and 106 are asked twice
+	require.Equal(t, 8, replayer.Served("getBlock"))
+}
+
+type getBlockCounter struct {
+	sync.Mutex
+	calls   map[uint64]int
+	methods map[string]int
+}
+
+func newGetBlockCounter() *getBlockCounter {
+	return &getBlockCounter{calls: map[uint64]int{}, methods: map[string]int{}}
+}
+
+func newGetBlockServer(t *testing.T, headSlot uint64, counter *getBlockCounter) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		counter.Lock()
+		counter.methods[req.Method]++
+		counter.Unlock()
+
+		var result string
+		switch req.Method {
+		case "getSlot":
+			result = fmt.Sprintf("%d", headSlot)
+		case "getBlock":
+			slot := uint64(req.Params[0].(float64))
+			counter.Lock()
+			counter.calls[slot]++
+			counter.Unlock()
+			result = fmt.Sprintf(`{"blockhash":"11111111111111111111111111111111","previousBlockhash":"11111111111111111111111111111111","parentSlot":%d,"transactions":[],"rewards":[]}`, slot-1)
+		default:
+			t.Errorf("unexpected method %q", req.Method)
+		}
+
+		id, _ := json.Marshal(req.ID)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, id, result)
+	}))
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 1s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func Test_RPCFetcherPrefetch(t *testing.T) {
+	counter := newGetBlockCounter()
+	server := newGetBlockServer(t, 100, counter)
+	defer server.Close()
+
+	clients := NewRPCClients("test")
+	clients.Add("a", rpc.New(server.URL))
+	clients.Add("b", rpc.New(server.URL))
+	f := NewRPC(clients, clients, 0, time.Millisecond, 5, DefaultRetryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+
+	ctx := context.Background()
+	for slot := uint64(10); slot < 13; slot++ {
+		block, skip, err := f.Fetch(ctx, slot)
+		require.NoError(t, err)
+		require.False(t, skip)
+		require.Equal(t, slot, block.Number)
+	}
+
+	waitFor(t, func() bool {
+		counter.Lock()
+		defer counter.Unlock()
+		return len(counter.calls) == 7
+	})
+
+	counter.Lock()
+	defer counter.Unlock()
+	for slot := uint64(10); slot < 17; slot++ {
+		require.Equal(t, 1, counter.calls[slot], "slot %d should have been fetched once", slot)
+	}
+}
+
+func Test_RPCFetcherPrefetchStopsAtHead(t *testing.T) {
+	counter := newGetBlockCounter()
+	server := newGetBlockServer(t, 11, counter)
+	defer server.Close()
+
+	clients := NewRPCClients("test")
+	clients.Add("a", rpc.New(server.URL))
+	f := NewRPC(clients, clients, 0, time.Millisecond, 10, DefaultRetryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+
+	_, _, err := f.Fetch(context.Background(), 10)
+	require.NoError(t, err)
+
+	waitFor(t, func() bool {
+		counter.Lock()
+		defer counter.Unlock()
+		return counter.calls[11] == 1
+	})
+
+	counter.Lock()
+	defer counter.Unlock()
+	require.Len(t, counter.calls, 2)
+}
+
+func Test_RPCFetcherWaitForSlotDoesNotHoldHead(t *testing.T) {
+	counter := newGetBlockCounter()
+	server := newGetBlockServer(t, 5, counter)
+	defer server.Close()
+
+	clients := NewRPCClients("test")
+	clients.Add("a", rpc.New(server.URL))
+	f := NewRPC(clients, clients, 0, time.Hour, 1, DefaultRetryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+	defer f.Close()
+
+	_, _, err := f.Fetch(context.Background(), 5)
+	require.NoError(t, err)
+
+	// Slot 10 is past the head, its fetch waits an hour before checking the head again
+	waitCtx, cancelWait := context.WithCancel(context.Background())
+	waitErr := make(chan error, 1)
+	go func() {
+		_, _, err := f.Fetch(waitCtx, 10)
+		waitErr <- err
+	}()
+	waitFor(t, func() bool {
+		counter.Lock()
+		defer counter.Unlock()
+		return counter.methods["getSlot"] == 3
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	block, _, err := f.Fetch(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), block.Number)
+	require.True(t, f.IsBlockAvailable(5))
+
+	cancelWait()
+	require.True(t, errors.Is(<-waitErr, context.Canceled))
+}
+
+func Test_RPCFetcherCloseCancelsPrefetches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var result string
+		switch req.Method {
+		case "getSlot":
+			result = "100"
+		case "getBlock":
+			slot := uint64(req.Params[0].(float64))
+			if slot > 10 {
+				// Prefetched slots never answer, until their request is canceled
+				<-r.Context().Done()
+				return
+			}
+			result = fmt.Sprintf(`{"blockhash":"11111111111111111111111111111111","previousBlockhash":"11111111111111111111111111111111","parentSlot":%d,"transactions":[],"rewards":[]}`, slot-1)
+		}
+
+		id, _ := json.Marshal(req.ID)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, id, result)
+	}))
+	defer server.Close()
+
+	clients := NewRPCClients("test")
+	clients.Add("a", rpc.New(server.URL))
+	f := NewRPC(clients, clients, 0, time.Millisecond, 2, DefaultRetryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+
+	// The prefetch of slot 11 outlives the Fetch call that started it
+	ctx, cancel := context.WithCancel(context.Background())
+	_, _, err := f.Fetch(ctx, 10)
+	require.NoError(t, err)
+	cancel()
+
+	f.prefetchLock.Lock()
+	prefetched := f.prefetched[11]
+	f.prefetchLock.Unlock()
+	require.NotNil(t, prefetched)
+
+	select {
+	case <-prefetched.done:
+		t.Fatal("prefetch of slot 11 should still be running")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	f.Close()
+	select {
+	case <-prefetched.done:
+		require.Error(t, prefetched.err)
+	case <-time.After(time.Second):
+		t.Fatal("prefetch of slot 11 should be canceled by Close")
+	}
+}
+
+func Test_RPCFetcherPerMethodEndpoints(t *testing.T) {
+	slotCounter := newGetBlockCounter()
+	slotServer := newGetBlockServer(t, 100, slotCounter)
+	defer slotServer.Close()
+
+	blockCounter := newGetBlockCounter()
+	blockServer := newGetBlockServer(t, 100, blockCounter)
+	defer blockServer.Close()
+
+	deadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer deadServer.Close()
+
+	// Each method falls back on its own endpoints when its first one fails
+	slotClients := NewRPCClients("slot")
+	slotClients.Add("dead", rpc.New(deadServer.URL))
+	slotClients.Add("slot", rpc.New(slotServer.URL))
+
+	blockClients := NewRPCClients("block")
+	blockClients.Add("dead", rpc.New(deadServer.URL))
+	blockClients.Add("block", rpc.New(blockServer.URL))
+
+	f := NewRPC(slotClients, blockClients, 0, time.Millisecond, 1, DefaultRetryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+	block, skip, err := f.Fetch(context.Background(), 10)
+	require.NoError(t, err)
+	require.False(t, skip)
+	require.Equal(t, uint64(10), block.Number)
+
+	slotCounter.Lock()
+	defer slotCounter.Unlock()
+	blockCounter.Lock()
+	defer blockCounter.Unlock()
+
+	require.Equal(t, 0, slotCounter.methods["getBlock"])
+	require.Equal(t, 2, slotCounter.methods["getSlot"])
+	require.Equal(t, 0, blockCounter.methods["getSlot"])
+	require.Equal(t, 1, blockCounter.methods["getBlock"])
+}
+
+func Test_RPCFetcherChainPatches(t *testing.T) {
+	counter := newGetBlockCounter()
+	server := newGetBlockServer(t, 100, counter)
+	defer server.Close()
+
+	chainPatches, err := patches.Parse([]byte(`version: 1
+skipped_slots:
+  - {first: 10, last: 11}
+previous_blockhashes:
+  - {blockhash: 11111111111111111111111111111111, previous_blockhash: HQEr9qcbUVBt2okfu755FdJvJrPYTSpzzmmyeWTj5oau}
+`))
+	require.NoError(t, err)
+
+	clients := NewRPCClients("test")
+	clients.Add("a", rpc.New(server.URL))
+	f := NewRPC(clients, clients, 0, time.Millisecond, 1, DefaultRetryConfig, DefaultRequestConfig, ConversionPolicyFail, chainPatches, zap.NewNop())
+
+	_, skip, err := f.Fetch(context.Background(), 11)
+	require.NoError(t, err)
+	require.True(t, skip)
+
+	block, skip, err := f.Fetch(context.Background(), 12)
+	require.NoError(t, err)
+	require.False(t, skip)
+	require.Equal(t, "HQEr9qcbUVBt2okfu755FdJvJrPYTSpzzmmyeWTj5oau", block.ParentId)
+
+	counter.Lock()
+	defer counter.Unlock()
+	require.Equal(t, map[uint64]int{12: 1}, counter.calls)
+}
+
+func Test_RPCFetcherFinalizedOnly(t *testing.T) {
+	var lock sync.Mutex
+	var blockCommitments []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		opts := req.Params[len(req.Params)-1].(map[string]any)
+
+		var result string
+		switch req.Method {
+		case "getSlot":
+			result = map[string]string{"confirmed": "100", "finalized": "10"}[opts["commitment"].(string)]
+		case "getBlock":
+			lock.Lock()
+			blockCommitments = append(blockCommitments, opts["commitment"].(string))
+			lock.Unlock()
+			result = fmt.Sprintf(`{"blockhash":"11111111111111111111111111111111","previousBlockhash":"11111111111111111111111111111111","parentSlot":%d,"transactions":[],"rewards":[]}`, uint64(req.Params[0].(float64))-1)
+		}
+
+		id, _ := json.Marshal(req.ID)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, id, result)
+	}))
+	defer server.Close()
+
+	clients := NewRPCClients("test")
+	clients.Add("a", rpc.New(server.URL))
+	f := NewRPC(clients, clients, 0, time.Millisecond, 10, DefaultRetryConfig, RequestConfig{Commitment: rpc.CommitmentFinalized}, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+
+	block, _, err := f.Fetch(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), block.Number)
+	require.False(t, f.IsBlockAvailable(11))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, _, err = f.Fetch(ctx, 11)
+	require.True(t, errors.Is(err, context.DeadlineExceeded), "slot 11 is confirmed but not finalized")
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Equal(t, []string{"finalized"}, blockCommitments)
+}
+
+func Test_RPCFetcherUnsupportedTransactionVersion(t *testing.T) {
+	cases := []struct {
+		name     string
+		response string
+	}{
+		{
+			name:     "rejected by endpoint",
+			response: `"error":{"code":-32015,"message":"Transaction version (1) is not supported by the requesting client"}`,
+		},
+		{
+			name:     "returned by endpoint",
+			response: `"result":{"blockhash":"11111111111111111111111111111111","previousBlockhash":"11111111111111111111111111111111","parentSlot":9,"transactions":[{"transaction":null,"meta":null,"version":1}],"rewards":[]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					ID     any    `json:"id"`
+					Method string `json:"method"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+				id, _ := json.Marshal(req.ID)
+				if req.Method == "getSlot" {
+					fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":100}`, id)
+					return
+				}
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,%s}`, id, c.response)
+			}))
+			defer server.Close()
+
+			clients := NewRPCClients("test")
+			clients.Add("a", rpc.New(server.URL))
+			f := NewRPC(clients, clients, 0, time.Millisecond, 1, DefaultRetryConfig, DefaultRequestConfig, ConversionPolicyFail, &patches.Registry{}, zap.NewNop())
+
+			_, _, err := f.Fetch(context.Background(), 10)
+			var versionErr *UnsupportedTransactionVersionError
+			require.True(t, errors.As(err, &versionErr), "unexpected error %v", err)
+			require.Equal(t, uint64(10), versionErr.Slot)
+			require.Equal(t, uint64(0), versionErr.MaxSupportedTransactionVersion)
+		})
+	}
 }
 
 func Test_TrxErrorEncode(t *testing.T) {
@@ -0,0 +1,251 @@
+package fetcher
+
+import (
+	"archive/tar"
+	"compress/bzip2"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/firehose-solana/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"go.uber.org/zap"
+)
+
+// WarehouseArchive is a ledger archive of a warehouse store, laid out as the Solana warehouse
+// nodes upload them: `<first-slot>/rocksdb.tar.bz2` (or `.tar.zst`) next to an optional
+// `<first-slot>/bounds.txt` holding the output of `solana-ledger-tool bounds`.
+type WarehouseArchive struct {
+	FirstSlot uint64
+	// LastSlot is the last rooted slot according to bounds.txt, 0 when unknown
+	LastSlot uint64
+	Path     string
+}
+
+var (
+	warehouseRootedBoundsRegex = regexp.MustCompile(`rooted slots from (\d+) to (\d+)`)
+	warehouseBoundsRegex       = regexp.MustCompile(`slots (\d+) to (\d+)`)
+)
+
+// ListWarehouseArchives returns the archives of store, sorted by first slot.
+func ListWarehouseArchives(ctx context.Context, store dstore.Store) ([]*WarehouseArchive, error) {
+	var archives []*WarehouseArchive
+	err := store.Walk(ctx, "", func(filename string) error {
+		dir, name := path.Split(filename)
+		if name != "rocksdb.tar.bz2" && name != "rocksdb.tar.zst" {
+			return nil
+		}
+
+		firstSlot, err := strconv.ParseUint(strings.TrimSuffix(path.Base(dir), "/"), 10, 64)
+		if err != nil {
+			return nil
+		}
+		archives = append(archives, &WarehouseArchive{FirstSlot: firstSlot, Path: filename})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking warehouse store: %w", err)
+	}
+
+	sort.Slice(archives, func(i, j int) bool { return archives[i].FirstSlot < archives[j].FirstSlot })
+
+	for _, archive := range archives {
+		boundsFile := path.Join(path.Dir(archive.Path), "bounds.txt")
+		exists, err := store.FileExists(ctx, boundsFile)
+		if err != nil {
+			return nil, fmt.Errorf("checking %s: %w", boundsFile, err)
+		}
+		if !exists {
+			continue
+		}
+
+		archive.LastSlot, err = readWarehouseBounds(ctx, store, boundsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return archives, nil
+}
+
+func readWarehouseBounds(ctx context.Context, store dstore.Store, filename string) (uint64, error) {
+	reader, err := store.OpenObject(ctx, filename)
+	if err != nil {
+		return 0, fmt.Errorf("opening %s: %w", filename, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, fmt.Errorf("reading %s: %w", filename, err)
+	}
+
+	match := warehouseRootedBoundsRegex.FindSubmatch(content)
+	if match == nil {
+		match = warehouseBoundsRegex.FindSubmatch(content)
+	}
+	if match == nil {
+		return 0, fmt.Errorf("no slots range found in %s", filename)
+	}
+	return strconv.ParseUint(string(match[2]), 10, 64)
+}
+
+// ExtractWarehouseArchive streams archive from store, extracting it to destDir.
+func ExtractWarehouseArchive(ctx context.Context, store dstore.Store, archive *WarehouseArchive, destDir string) error {
+	object, err := store.OpenObject(ctx, archive.Path)
+	if err != nil {
+		return fmt.Errorf("opening archive %s: %w", archive.Path, err)
+	}
+	defer object.Close()
+
+	var reader io.Reader = bzip2.NewReader(object)
+	if strings.HasSuffix(archive.Path, ".zst") {
+		decoder, err := zstd.NewReader(object)
+		if err != nil {
+			return fmt.Errorf("decompressing archive %s: %w", archive.Path, err)
+		}
+		defer decoder.Close()
+		reader = decoder
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading archive %s: %w", archive.Path, err)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		name := filepath.Clean(header.Name)
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("archive %s has an entry outside of its root: %s", archive.Path, header.Name)
+		}
+		target := filepath.Join(destDir, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return fmt.Errorf("creating %s: %w", target, err)
+			}
+		case tar.TypeReg:
+			if err := extractFile(tarReader, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractFile(reader io.Reader, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(target), err)
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", target, err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, reader); err != nil {
+		return fmt.Errorf("extracting %s: %w", target, err)
+	}
+	return file.Close()
+}
+
+// WarehouseReader reads rooted blocks out of the ledger archives of a warehouse store, one
+// archive after the other, each one being extracted to a work directory then removed once read.
+type WarehouseReader struct {
+	store      dstore.Store
+	workDir    string
+	openLedger func(ledgerDir string) (LedgerDB, error)
+	patches    *patches.Registry
+	logger     *zap.Logger
+}
+
+// NewWarehouseReader creates a reader extracting the archives of store under workDir, opening
+// them with openLedger, usually OpenRocksDBLedger.
+func NewWarehouseReader(store dstore.Store, workDir string, openLedger func(ledgerDir string) (LedgerDB, error), chainPatches *patches.Registry, logger *zap.Logger) *WarehouseReader {
+	return &WarehouseReader{
+		store:      store,
+		workDir:    workDir,
+		openLedger: openLedger,
+		patches:    chainPatches,
+		logger:     logger,
+	}
+}
+
+// Read calls processBlock with each rooted block from startSlot on, until processBlock returns
+// an error, which Read returns wrapped, or archives run out, Read then returning nil. An
+// archive is read up to the first slot of the next one, or its last rooted slot for the last
+// archive, which must then have bounds.
+func (r *WarehouseReader) Read(ctx context.Context, startSlot uint64, processBlock func(block *pbsol.Block) error) error {
+	archives, err := ListWarehouseArchives(ctx, r.store)
+	if err != nil {
+		return err
+	}
+	if len(archives) == 0 || archives[0].FirstSlot > startSlot {
+		return fmt.Errorf("no warehouse archive holds slot %d", startSlot)
+	}
+
+	var previous *pbsol.Block
+	next := startSlot
+	for i, archive := range archives {
+		last := archive.LastSlot
+		if i+1 < len(archives) {
+			following := archives[i+1].FirstSlot
+			if last != 0 && last+1 < following {
+				return fmt.Errorf("slots %d to %d are in no warehouse archive, %s ends at %d", last+1, following-1, archive.Path, last)
+			}
+			last = following - 1
+		}
+		if last == 0 {
+			return fmt.Errorf("last slot of warehouse archive %s is unknown, it has no bounds.txt", archive.Path)
+		}
+		if last < next {
+			continue
+		}
+
+		previous, err = r.readArchive(ctx, archive, previous, max(next, archive.FirstSlot), last, processBlock)
+		if err != nil {
+			return err
+		}
+		next = last + 1
+	}
+	return nil
+}
+
+func (r *WarehouseReader) readArchive(ctx context.Context, archive *WarehouseArchive, previous *pbsol.Block, startSlot, stopSlot uint64, processBlock func(block *pbsol.Block) error) (*pbsol.Block, error) {
+	ledgerDir := filepath.Join(r.workDir, strconv.FormatUint(archive.FirstSlot, 10))
+	defer os.RemoveAll(ledgerDir)
+
+	r.logger.Info("extracting warehouse archive", zap.String("archive", archive.Path), zap.String("ledger_dir", ledgerDir))
+	if err := ExtractWarehouseArchive(ctx, r.store, archive, ledgerDir); err != nil {
+		return nil, err
+	}
+
+	db, err := r.openLedger(ledgerDir)
+	if err != nil {
+		return nil, fmt.Errorf("opening ledger of archive %s: %w", archive.Path, err)
+	}
+	defer db.Close()
+
+	r.logger.Info("reading warehouse archive", zap.String("archive", archive.Path), zap.Uint64("start_slot", startSlot), zap.Uint64("stop_slot", stopSlot))
+	last, err := NewLedgerReader(db, r.patches, r.logger).ReadFrom(ctx, previous, startSlot, stopSlot, processBlock)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive %s: %w", archive.Path, err)
+	}
+	return last, nil
+}
@@ -0,0 +1,109 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/firehose-solana/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestWarehouseStore(t *testing.T) dstore.Store {
+	dir, err := filepath.Abs(filepath.Join("testdata", "warehouse"))
+	require.NoError(t, err)
+
+	store, err := dstore.NewStore("file://"+dir, "", "", false)
+	require.NoError(t, err)
+	return store
+}
+
+func Test_ListWarehouseArchives(t *testing.T) {
+	archives, err := ListWarehouseArchives(context.Background(), newTestWarehouseStore(t))
+	require.NoError(t, err)
+
+	require.Equal(t, []*WarehouseArchive{
+		{FirstSlot: 10, LastSlot: 12, Path: "10/rocksdb.tar.bz2"},
+		{FirstSlot: 13, LastSlot: 13, Path: "13/rocksdb.tar.bz2"},
+	}, archives)
+}
+
+func Test_ExtractWarehouseArchive(t *testing.T) {
+	destDir := t.TempDir()
+	err := ExtractWarehouseArchive(context.Background(), newTestWarehouseStore(t), &WarehouseArchive{FirstSlot: 10, Path: "10/rocksdb.tar.bz2"}, destDir)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(destDir, "rocksdb", "CURRENT"))
+	require.NoError(t, err)
+	require.Equal(t, "MANIFEST-000001\n", string(content))
+}
+
+// testWarehouseLedgers opens the in-memory ledger of each fixture archive, the second one
+// holding slot 13 but not its parent, whose blockhash comes from the first archive.
+func testWarehouseLedgers(t *testing.T, opened *[]string) func(ledgerDir string) (LedgerDB, error) {
+	ledgers := map[string]memLedger{"10": newTestLedger(t), "13": {}}
+	ledgers["13"].put(ledgerRootColumn, ledgerSlotKey(13), []byte{1})
+	ledgers["13"].putSlot(13, 12, 1000, encodeEntries(testEntry{hash: 0x0d}))
+
+	return func(ledgerDir string) (LedgerDB, error) {
+		if _, err := os.Stat(filepath.Join(ledgerDir, "rocksdb", "CURRENT")); err != nil {
+			return nil, fmt.Errorf("archive not extracted: %w", err)
+		}
+
+		*opened = append(*opened, filepath.Base(ledgerDir))
+		return ledgers[filepath.Base(ledgerDir)], nil
+	}
+}
+
+func Test_WarehouseReaderRead(t *testing.T) {
+	var opened []string
+	workDir := t.TempDir()
+	reader := NewWarehouseReader(newTestWarehouseStore(t), workDir, testWarehouseLedgers(t, &opened), &patches.Registry{}, zap.NewNop())
+
+	var blocks []*pbsol.Block
+	err := reader.Read(context.Background(), 10, func(block *pbsol.Block) error {
+		blocks = append(blocks, block)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"10", "13"}, opened)
+	require.Len(t, blocks, 3)
+	require.Equal(t, []uint64{10, 12, 13}, []uint64{blocks[0].Slot, blocks[1].Slot, blocks[2].Slot})
+	require.Equal(t, blocks[1].Blockhash, blocks[2].PreviousBlockhash, "previous blockhash carries across archives")
+
+	entries, err := os.ReadDir(workDir)
+	require.NoError(t, err)
+	require.Empty(t, entries, "extracted archives are removed once read")
+}
+
+func Test_WarehouseReaderStop(t *testing.T) {
+	var opened []string
+	reader := NewWarehouseReader(newTestWarehouseStore(t), t.TempDir(), testWarehouseLedgers(t, &opened), &patches.Registry{}, zap.NewNop())
+
+	var slots []uint64
+	err := reader.Read(context.Background(), 12, func(block *pbsol.Block) error {
+		if block.Slot >= 13 {
+			return io.EOF
+		}
+		slots = append(slots, block.Slot)
+		return nil
+	})
+	require.True(t, errors.Is(err, io.EOF), "processBlock error is returned wrapped")
+	require.Equal(t, []uint64{12}, slots)
+}
+
+func Test_WarehouseReaderErrors(t *testing.T) {
+	reader := NewWarehouseReader(newTestWarehouseStore(t), t.TempDir(), nil, &patches.Registry{}, zap.NewNop())
+
+	err := reader.Read(context.Background(), 5, func(block *pbsol.Block) error { return nil })
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no warehouse archive holds slot 5")
+}
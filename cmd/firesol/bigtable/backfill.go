@@ -0,0 +1,112 @@
+package bigtable
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+func NewBackfillCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bigtable-backfill <destination> <start> <stop>",
+		Short: "write merged blocks for [start, stop) read from Bigtable, splitting the range in shards read concurrently",
+		Long: "Write merged blocks for [start, stop) read from Bigtable. The range is rounded to 100 blocks bundle boundaries " +
+			"and split in --shards contiguous shards, at most --workers of them being read at the same time. Each shard writes its " +
+			"own bundles to the destination store.",
+		Args: cobra.ExactArgs(3),
+		RunE: backfillRunE(logger, tracer),
+	}
+
+	addBigtableFlags(cmd)
+	patches.AddFlags(cmd)
+	cmd.Flags().Int("shards", 64, "Number of shards to split the range in")
+	cmd.Flags().Int("workers", 8, "Maximum number of shards read concurrently")
+
+	return cmd
+}
+
+func backfillRunE(logger *zap.Logger, tracer logging.Tracer) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		destination := args[0]
+		destStore, err := dstore.NewStore(destination, "dbin.zst", "zstd", true)
+		if err != nil {
+			return fmt.Errorf("reading destination store: %w", err)
+		}
+
+		start, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing start block num: %w", err)
+		}
+		stop, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing stop block num: %w", err)
+		}
+
+		shardCount := sflags.MustGetInt(cmd, "shards")
+		workers := sflags.MustGetInt(cmd, "workers")
+		if shardCount <= 0 || workers <= 0 {
+			return fmt.Errorf("--shards and --workers must be greater than 0")
+		}
+
+		shards := fetcher.SplitInShards(start, stop, shardCount)
+		if len(shards) == 0 {
+			return fmt.Errorf("empty range [%d, %d)", start, stop)
+		}
+
+		logger.Info("starting bigtable backfill",
+			zap.String("destination", destination),
+			zap.Uint64("start", shards[0].Start),
+			zap.Uint64("stop", shards[len(shards)-1].Stop),
+			zap.Int("shards", len(shards)),
+			zap.Int("workers", workers),
+		)
+
+		chainPatches, err := patches.LoadFromFlags(cmd, logger)
+		if err != nil {
+			return err
+		}
+
+		client, err := newBigtableClient(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		reader := fetcher.NewBigtableReader(client, sflags.MustGetUint64(cmd, "max-connection-attempts"), chainPatches, logger, tracer)
+
+		err = reader.ReadShards(ctx, shards, workers, func(shard fetcher.BlockRange) func(block *pbsol.Block) error {
+			writer := &firecore.MergedBlocksWriter{
+				Cmd:          cmd,
+				Store:        destStore,
+				LowBlockNum:  shard.Start,
+				StopBlockNum: shard.Stop,
+				Logger:       logger.With(zap.Stringer("shard", shard)),
+			}
+
+			return func(block *pbsol.Block) error {
+				blk, err := fetcher.BstreamBlockFromBlock(block)
+				if err != nil {
+					return fmt.Errorf("converting block %d: %w", block.Slot, err)
+				}
+				return writer.ProcessBlock(blk, nil)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("backfilling: %w", err)
+		}
+
+		logger.Info("bigtable backfill completed")
+		return nil
+	}
+}
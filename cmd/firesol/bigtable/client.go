@@ -0,0 +1,31 @@
+package bigtable
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigtable"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	"google.golang.org/api/option"
+)
+
+func addBigtableFlags(cmd *cobra.Command) {
+	cmd.Flags().String("bt-project", "mainnet-beta", "Bigtable project id")
+	cmd.Flags().String("bt-instance", "solana-ledger", "Bigtable instance id")
+	cmd.Flags().String("bt-credentials-file", "", "Path to a Google Cloud service account JSON credentials file, uses application default credentials when empty")
+	cmd.Flags().Uint64("max-connection-attempts", 10, "Number of attempts to read from Bigtable before giving up")
+}
+
+func newBigtableClient(ctx context.Context, cmd *cobra.Command) (*bigtable.Client, error) {
+	var opts []option.ClientOption
+	if credentialsFile := sflags.MustGetString(cmd, "bt-credentials-file"); credentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credentialsFile))
+	}
+
+	client, err := bigtable.NewClient(ctx, sflags.MustGetString(cmd, "bt-project"), sflags.MustGetString(cmd, "bt-instance"), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating bigtable client: %w", err)
+	}
+	return client, nil
+}
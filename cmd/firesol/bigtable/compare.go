@@ -0,0 +1,202 @@
+package bigtable
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+func NewCompareSourcesCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare-sources <rpc-endpoint> <start> <stop>",
+		Short: "compare the blocks of [start, stop) converted from Bigtable and from an RPC endpoint, reporting their field-level differences",
+		Long: "Read the [start, stop) blocks from Bigtable, fetch the same slots from rpc-endpoint at finalized commitment, and " +
+			"print the protobuf fields differing between both conversions of each slot, Bigtable's being the reference. Rewards " +
+			"are sorted on both sides first, Bigtable keeping the validator's order. The " +
+			"slots rpc-endpoint lists with getBlocks are walked as well, a slot holding a block on one side only being " +
+			"reported as missing from the other. A summary counting the differences by field is printed at the end, the " +
+			"command failing when any was found.",
+		Args: cobra.ExactArgs(3),
+		RunE: compareSourcesRunE(logger, tracer),
+	}
+
+	addBigtableFlags(cmd)
+	patches.AddFlags(cmd)
+	cmd.Flags().StringArray("ignore-field", nil, "Field path without indexes, like 'transactions.meta.log_messages', whose differences are not reported, can be repeated")
+	cmd.Flags().Int("max-diffs-per-block", 20, "Maximum number of differences printed for a block, all of them being counted in the summary")
+
+	return cmd
+}
+
+func compareSourcesRunE(logger *zap.Logger, tracer logging.Tracer) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		endpoint := args[0]
+		start, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing start slot: %w", err)
+		}
+		stop, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing stop slot: %w", err)
+		}
+		if stop <= start {
+			return fmt.Errorf("stop slot %d must be greater than start slot %d", stop, start)
+		}
+
+		ignoredFields := sflags.MustGetStringArray(cmd, "ignore-field")
+		maxDiffsPerBlock := sflags.MustGetInt(cmd, "max-diffs-per-block")
+
+		chainPatches, err := patches.LoadFromFlags(cmd, logger)
+		if err != nil {
+			return err
+		}
+
+		client, err := newBigtableClient(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		reader := fetcher.NewBigtableReader(client, sflags.MustGetUint64(cmd, "max-connection-attempts"), chainPatches, logger, tracer)
+
+		rpcClient := rpc.New(endpoint)
+		clients := fetcher.NewRPCClients("compare")
+		clients.Add("rpc", rpcClient)
+
+		// Bigtable only holds finalized blocks, RPC ones are fetched at the same commitment
+		requestConfig := fetcher.DefaultRequestConfig
+		requestConfig.Commitment = rpc.CommitmentFinalized
+		rpcFetcher := fetcher.NewRPC(clients, clients, 0, time.Second, 1, fetcher.DefaultRetryConfig, requestConfig, fetcher.ConversionPolicyFail, chainPatches, logger)
+		defer rpcFetcher.Close()
+
+		var blocks, differingBlocks int
+		fieldCounts := map[string]int{}
+
+		logger.Info("comparing sources", zap.Uint64("start", start), zap.Uint64("stop", stop), zap.Strings("ignored_fields", ignoredFields))
+		rpcSlots, err := finalizedSlots(ctx, rpcClient, start, stop)
+		if err != nil {
+			return err
+		}
+
+		// rpcSlots are walked along Bigtable's rows, next being the first one not reached yet
+		next := 0
+		reportMissingFromBigtable := func(below uint64) {
+			for ; next < len(rpcSlots) && rpcSlots[next] < below; next++ {
+				fmt.Printf("slot %d: present in rpc, missing from Bigtable\n", rpcSlots[next])
+				blocks++
+				differingBlocks++
+				fieldCounts["<block>"]++
+			}
+		}
+
+		err = reader.Read(ctx, start, stop, func(bigtableBlock *pbsol.Block) error {
+			if bigtableBlock.Slot >= stop {
+				return nil
+			}
+			blocks++
+
+			reportMissingFromBigtable(bigtableBlock.Slot)
+			if next == len(rpcSlots) || rpcSlots[next] != bigtableBlock.Slot {
+				fmt.Printf("slot %d: present in Bigtable, missing from rpc\n", bigtableBlock.Slot)
+				differingBlocks++
+				fieldCounts["<block>"]++
+				return nil
+			}
+			next++
+
+			blk, skip, err := rpcFetcher.Fetch(ctx, bigtableBlock.Slot)
+			if err != nil {
+				return fmt.Errorf("fetching slot %d from rpc: %w", bigtableBlock.Slot, err)
+			}
+			if skip {
+				fmt.Printf("slot %d: present in Bigtable, skipped by rpc\n", bigtableBlock.Slot)
+				differingBlocks++
+				fieldCounts["<block>"]++
+				return nil
+			}
+
+			rpcBlock := &pbsol.Block{}
+			if err := blk.Payload.UnmarshalTo(rpcBlock); err != nil {
+				return fmt.Errorf("unpacking rpc block %d: %w", bigtableBlock.Slot, err)
+			}
+
+			// Bigtable keeps the rewards in the validator's order, the RPC conversion sorts them
+			fetcher.NormalizeForCompare(bigtableBlock)
+			fetcher.NormalizeForCompare(rpcBlock)
+			diffs := fetcher.FilterDiffs(fetcher.DiffMessages(bigtableBlock, rpcBlock), ignoredFields)
+			if len(diffs) == 0 {
+				return nil
+			}
+
+			differingBlocks++
+			fmt.Printf("slot %d: %d differences (bigtable != rpc)\n", bigtableBlock.Slot, len(diffs))
+			for i, diff := range diffs {
+				fieldCounts[diff.Field()]++
+				if i < maxDiffsPerBlock {
+					fmt.Printf("  %s\n", diff)
+				}
+			}
+			if len(diffs) > maxDiffsPerBlock {
+				fmt.Printf("  ... %d more\n", len(diffs)-maxDiffsPerBlock)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("comparing sources: %w", err)
+		}
+		reportMissingFromBigtable(stop)
+
+		fmt.Printf("\ncompared %d blocks, %d differing\n", blocks, differingBlocks)
+		if differingBlocks == 0 {
+			return nil
+		}
+
+		fields := make([]string, 0, len(fieldCounts))
+		for field := range fieldCounts {
+			fields = append(fields, field)
+		}
+		sort.Slice(fields, func(i, j int) bool {
+			if fieldCounts[fields[i]] != fieldCounts[fields[j]] {
+				return fieldCounts[fields[i]] > fieldCounts[fields[j]]
+			}
+			return fields[i] < fields[j]
+		})
+		for _, field := range fields {
+			fmt.Printf("  %-60s %d\n", field, fieldCounts[field])
+		}
+
+		return fmt.Errorf("sources differ on %d of %d blocks", differingBlocks, blocks)
+	}
+}
+
+// getBlocksMaxRange is the largest range of slots an RPC node lists in one getBlocks call.
+const getBlocksMaxRange = 500_000
+
+// finalizedSlots lists the slots of [start, stop) holding a finalized block according to client,
+// in order.
+func finalizedSlots(ctx context.Context, client *rpc.Client, start, stop uint64) ([]uint64, error) {
+	var slots []uint64
+	for from := start; from < stop; from += getBlocksMaxRange {
+		to := min(from+getBlocksMaxRange, stop) - 1
+		result, err := client.GetBlocks(ctx, from, &to, rpc.CommitmentFinalized)
+		if err != nil {
+			return nil, fmt.Errorf("listing rpc blocks of [%d, %d]: %w", from, to, err)
+		}
+		slots = append(slots, result...)
+	}
+	return slots, nil
+}
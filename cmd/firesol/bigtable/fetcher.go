@@ -0,0 +1,119 @@
+package bigtable
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/cli/sflags"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-core/blockpoller"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/patches"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+func NewFetchCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bigtable <first-streamable-block> [<stop-block>]",
+		Short: "fetch blocks from a Solana Bigtable instance",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  fetchRunE(logger, tracer),
+	}
+
+	addBigtableFlags(cmd)
+	patches.AddFlags(cmd)
+	cmd.Flags().String("state-dir", "/data/poller", "directory where the poller persists its state to resume from")
+	cmd.Flags().Duration("latest-block-retry-interval", 5*time.Second, "interval between checks for a block not yet available in Bigtable")
+	cmd.Flags().Int("block-fetch-batch-size", 10, "Number of blocks to fetch in a single batch")
+
+	return cmd
+}
+
+func fetchRunE(logger *zap.Logger, tracer logging.Tracer) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		ctx := cmd.Context()
+
+		stateDir := sflags.MustGetString(cmd, "state-dir")
+
+		startBlock, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to parse first streamable block %q: %w", args[0], err)
+		}
+
+		var stopBlock uint64
+		if len(args) > 1 {
+			stopBlock, err = strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("unable to parse stop block %q: %w", args[1], err)
+			}
+		}
+
+		project := sflags.MustGetString(cmd, "bt-project")
+		instance := sflags.MustGetString(cmd, "bt-instance")
+		maxConnectionAttempts := sflags.MustGetUint64(cmd, "max-connection-attempts")
+		latestBlockRetryInterval := sflags.MustGetDuration(cmd, "latest-block-retry-interval")
+
+		logger.Info(
+			"launching firehose-solana bigtable poller",
+			zap.String("state_dir", stateDir),
+			zap.Uint64("first_streamable_block", startBlock),
+			zap.Uint64("stop_block", stopBlock),
+			zap.String("bt_project", project),
+			zap.String("bt_instance", instance),
+			zap.Uint64("max_connection_attempts", maxConnectionAttempts),
+			zap.Duration("latest_block_retry_interval", latestBlockRetryInterval),
+		)
+
+		chainPatches, err := patches.LoadFromFlags(cmd, logger)
+		if err != nil {
+			return err
+		}
+
+		client, err := newBigtableClient(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		reader := fetcher.NewBigtableReader(client, maxConnectionAttempts, chainPatches, logger, tracer)
+
+		handler := &stopBlockHandler{
+			BlockHandler: blockpoller.NewFireBlockHandler("type.googleapis.com/sf.solana.type.v1.Block"),
+			stopBlock:    stopBlock,
+		}
+
+		poller := blockpoller.New(
+			fetcher.NewBigtableFetcher(reader, stopBlock, latestBlockRetryInterval, logger),
+			handler,
+			blockpoller.WithStoringState(stateDir),
+			blockpoller.WithBlockFetchRetryCount(maxConnectionAttempts),
+			blockpoller.WithLogger(logger),
+		)
+		handler.poller = poller
+
+		return fetcher.RunPoller(ctx, poller, startBlock, sflags.MustGetInt(cmd, "block-fetch-batch-size"))
+	}
+}
+
+// stopBlockHandler terminates the poller once the stop block has been handled, the poller
+// itself has no notion of a stop block.
+type stopBlockHandler struct {
+	blockpoller.BlockHandler
+	stopBlock uint64
+	poller    *blockpoller.BlockPoller
+}
+
+func (h *stopBlockHandler) Handle(blk *pbbstream.Block) error {
+	if err := h.BlockHandler.Handle(blk); err != nil {
+		return err
+	}
+
+	if h.stopBlock != 0 && blk.Number >= h.stopBlock {
+		h.poller.Shutdown(nil)
+	}
+	return nil
+}
@@ -0,0 +1,428 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-core/types"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+)
+
+func NewCompareMergedBlocksCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare-merged-blocks <ref-store> <current-store> <range>",
+		Short: "compare the merged blocks of two stores over <start>:<stop>, writing the mismatched slots and their differing fields to a JSON report",
+		Long: "Compare the merged blocks of current-store to the ones of ref-store over <start>:<stop>, rounded to 100 blocks " +
+			"bundle boundaries. Bundles are read from both stores and compared concurrently, rewards being sorted by lamports " +
+			"on both sides first. Blocks of a slot are matched by id, stores possibly holding forked blocks. Blocks missing " +
+			"from current-store, extra in it or different are written to the --report file with their field-level differences and summary counts. The report also lists the bundles " +
+			"compared, a run with an existing report resuming where it stopped.",
+		Args: cobra.ExactArgs(3),
+		RunE: compareMergedBlocksRunE(logger),
+	}
+
+	cmd.Flags().String("report", "compare-merged-blocks.json", "Path of the JSON report, resumed from when it exists")
+	cmd.Flags().Int("workers", 8, "Maximum number of bundles compared concurrently")
+	cmd.Flags().StringArray("ignore-field", nil, "Field path without indexes, like 'transactions.meta.log_messages', whose differences are not reported, can be repeated")
+	cmd.Flags().Duration("report-interval", 30*time.Second, "Interval at which the report is saved while comparing")
+
+	return cmd
+}
+
+// compareReport is the report of compare-merged-blocks, saved and resumed from as JSON.
+type compareReport struct {
+	Reference string `json:"reference"`
+	Current   string `json:"current"`
+	// ComparedBundles lists the base block of the bundles compared so far, skipped when resuming
+	ComparedBundles []uint64         `json:"compared_bundles"`
+	Mismatches      []*blockMismatch `json:"mismatches"`
+	Summary         *compareSummary  `json:"summary"`
+}
+
+type compareSummary struct {
+	ComparedBlocks   int `json:"compared_blocks"`
+	MismatchedBlocks int `json:"mismatched_blocks"`
+	// Kinds counts the mismatched blocks by kind
+	Kinds map[string]int `json:"kinds"`
+	// Fields counts the differences by field path without indexes
+	Fields map[string]int `json:"fields"`
+}
+
+const (
+	mismatchMissing   = "missing"
+	mismatchExtra     = "extra"
+	mismatchDifferent = "different"
+)
+
+// blockMismatch is a block missing from the current store, only present in it, or different
+// from the reference one at the same slot.
+type blockMismatch struct {
+	Slot uint64 `json:"slot"`
+	// BlockID is the id of the reference block, or of the current one for an extra block, a
+	// store holding forked blocks for some slots
+	BlockID string               `json:"block_id,omitempty"`
+	Kind    string               `json:"kind"`
+	Diffs   []*fetcher.FieldDiff `json:"diffs,omitempty"`
+}
+
+func loadCompareReport(path, reference, current string) (*compareReport, error) {
+	report := &compareReport{
+		Reference: reference,
+		Current:   current,
+		Summary:   &compareSummary{Kinds: map[string]int{}, Fields: map[string]int{}},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return report, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading report: %w", err)
+	}
+
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("decoding report %s: %w", path, err)
+	}
+	if report.Reference != reference || report.Current != current {
+		return nil, fmt.Errorf("report %s compares %s to %s, not %s to %s, use another --report", path, report.Reference, report.Current, reference, current)
+	}
+	return report, nil
+}
+
+// add records the mismatches of a compared bundle.
+func (r *compareReport) add(bundle uint64, comparedBlocks int, mismatches []*blockMismatch) {
+	r.ComparedBundles = append(r.ComparedBundles, bundle)
+	r.Mismatches = append(r.Mismatches, mismatches...)
+	r.Summary.ComparedBlocks += comparedBlocks
+	r.Summary.MismatchedBlocks += len(mismatches)
+	for _, mismatch := range mismatches {
+		r.Summary.Kinds[mismatch.Kind]++
+		for _, diff := range mismatch.Diffs {
+			r.Summary.Fields[diff.Field()]++
+		}
+	}
+}
+
+// pending returns the base block of the bundles of [start, stop) not compared yet.
+func (r *compareReport) pending(start, stop uint64) []uint64 {
+	compared := map[uint64]bool{}
+	for _, bundle := range r.ComparedBundles {
+		compared[bundle] = true
+	}
+
+	var bundles []uint64
+	for _, shard := range fetcher.SplitInShards(start, stop, 1) {
+		for bundle := shard.Start; bundle < shard.Stop; bundle += 100 {
+			if !compared[bundle] {
+				bundles = append(bundles, bundle)
+			}
+		}
+	}
+	return bundles
+}
+
+// save writes the report to a temporary file renamed to path, an interrupted save leaving the
+// previous report intact.
+func (r *compareReport) save(path string) error {
+	sort.Slice(r.ComparedBundles, func(i, j int) bool { return r.ComparedBundles[i] < r.ComparedBundles[j] })
+	sort.SliceStable(r.Mismatches, func(i, j int) bool {
+		if r.Mismatches[i].Slot != r.Mismatches[j].Slot {
+			return r.Mismatches[i].Slot < r.Mismatches[j].Slot
+		}
+		return r.Mismatches[i].BlockID < r.Mismatches[j].BlockID
+	})
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+	if err := os.WriteFile(path+".tmp", append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func compareMergedBlocksRunE(logger *zap.Logger) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		reference, current := args[0], args[1]
+		referenceStore, err := dstore.NewDBinStore(reference)
+		if err != nil {
+			return fmt.Errorf("reading reference store: %w", err)
+		}
+		currentStore, err := dstore.NewDBinStore(current)
+		if err != nil {
+			return fmt.Errorf("reading current store: %w", err)
+		}
+
+		blockRange, err := types.GetBlockRangeFromArg(args[2])
+		if err != nil {
+			return fmt.Errorf("parsing range: %w", err)
+		}
+		if !blockRange.IsResolved() {
+			return fmt.Errorf("invalid range %s, it must be a closed <start>:<stop> range", blockRange)
+		}
+
+		workers := sflags.MustGetInt(cmd, "workers")
+		if workers <= 0 {
+			return fmt.Errorf("--workers must be greater than 0")
+		}
+		ignoredFields := sflags.MustGetStringArray(cmd, "ignore-field")
+		reportInterval := sflags.MustGetDuration(cmd, "report-interval")
+
+		reportPath := sflags.MustGetString(cmd, "report")
+		report, err := loadCompareReport(reportPath, reference, current)
+		if err != nil {
+			return err
+		}
+		bundles := report.pending(uint64(blockRange.Start), blockRange.GetStopBlockOr(0))
+
+		logger.Info("comparing merged blocks",
+			zap.String("reference", reference),
+			zap.String("current", current),
+			zap.Stringer("range", blockRange),
+			zap.Int("bundles", len(bundles)),
+			zap.Int("already_compared_bundles", len(report.ComparedBundles)),
+			zap.Int("workers", workers),
+		)
+
+		comparer := &mergedBlocksComparer{
+			referenceStore: referenceStore,
+			currentStore:   currentStore,
+			ignoredFields:  ignoredFields,
+			report:         report,
+			reportPath:     reportPath,
+			reportInterval: reportInterval,
+			logger:         logger,
+		}
+		if err := comparer.compare(ctx, bundles, workers); err != nil {
+			return err
+		}
+
+		fields := make([]string, 0, len(report.Summary.Fields))
+		for field := range report.Summary.Fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		fmt.Printf("\n%d blocks compared, %d mismatched, report written to %s\n", report.Summary.ComparedBlocks, report.Summary.MismatchedBlocks, reportPath)
+		for _, kind := range []string{mismatchMissing, mismatchExtra, mismatchDifferent} {
+			if count := report.Summary.Kinds[kind]; count > 0 {
+				fmt.Printf("  %6d %s\n", count, kind)
+			}
+		}
+		for _, field := range fields {
+			fmt.Printf("  %6d %s\n", report.Summary.Fields[field], field)
+		}
+
+		if report.Summary.MismatchedBlocks > 0 {
+			return fmt.Errorf("stores differ on %d of %d blocks", report.Summary.MismatchedBlocks, report.Summary.ComparedBlocks)
+		}
+		return nil
+	}
+}
+
+// mergedBlocksComparer compares the bundles of two merged blocks stores, recording their
+// mismatches to report, saved to reportPath each reportInterval.
+type mergedBlocksComparer struct {
+	referenceStore dstore.Store
+	currentStore   dstore.Store
+	ignoredFields  []string
+	report         *compareReport
+	reportPath     string
+	reportInterval time.Duration
+	logger         *zap.Logger
+
+	lock     sync.Mutex
+	lastSave time.Time
+}
+
+// compare compares bundles, workers of them at a time, saving the report once done, or once
+// one fails, the next run resuming after the bundles compared.
+func (c *mergedBlocksComparer) compare(ctx context.Context, bundles []uint64, workers int) error {
+	c.lastSave = time.Now()
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for _, bundle := range bundles {
+		bundle := bundle
+		group.Go(func() error {
+			comparedBlocks, mismatches, err := compareMergedBlocksBundle(groupCtx, c.referenceStore, c.currentStore, bundle, c.ignoredFields)
+			if err != nil {
+				return fmt.Errorf("comparing bundle %010d: %w", bundle, err)
+			}
+			if len(mismatches) > 0 {
+				c.logger.Info("bundle differs", zap.Uint64("bundle", bundle), zap.Int("mismatched_blocks", len(mismatches)))
+			}
+
+			c.lock.Lock()
+			defer c.lock.Unlock()
+
+			c.report.add(bundle, comparedBlocks, mismatches)
+			if time.Since(c.lastSave) < c.reportInterval {
+				return nil
+			}
+			c.lastSave = time.Now()
+			c.logger.Info("saving report", zap.Int("compared_bundles", len(c.report.ComparedBundles)), zap.Int("mismatched_blocks", c.report.Summary.MismatchedBlocks))
+			return c.report.save(c.reportPath)
+		})
+	}
+
+	compareErr := group.Wait()
+	if err := c.report.save(c.reportPath); err != nil {
+		return fmt.Errorf("saving report: %w", err)
+	}
+	return compareErr
+}
+
+// compareMergedBlocksBundle compares the blocks of the bundle starting at base in both stores,
+// returning the number of blocks compared and the mismatched ones. A bundle missing from a
+// store counts as holding no block. Blocks of a slot are matched by id, a store holding forked
+// blocks for it, a single unmatched block on each side being compared to the other one.
+func compareMergedBlocksBundle(ctx context.Context, referenceStore, currentStore dstore.Store, base uint64, ignoredFields []string) (int, []*blockMismatch, error) {
+	var referenceBlocks, currentBlocks map[uint64][]*mergedBlock
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() (err error) {
+		referenceBlocks, err = readMergedBlocksBundleBlocks(ctx, referenceStore, base)
+		if err != nil {
+			return fmt.Errorf("reading reference bundle: %w", err)
+		}
+		return nil
+	})
+	group.Go(func() (err error) {
+		currentBlocks, err = readMergedBlocksBundleBlocks(ctx, currentStore, base)
+		if err != nil {
+			return fmt.Errorf("reading current bundle: %w", err)
+		}
+		return nil
+	})
+	if err := group.Wait(); err != nil {
+		return 0, nil, err
+	}
+
+	slots := map[uint64]bool{}
+	for slot := range referenceBlocks {
+		slots[slot] = true
+	}
+	for slot := range currentBlocks {
+		slots[slot] = true
+	}
+
+	comparedBlocks := 0
+	var mismatches []*blockMismatch
+	for slot := range slots {
+		compared, slotMismatches := compareSlotBlocks(slot, referenceBlocks[slot], currentBlocks[slot], ignoredFields)
+		comparedBlocks += compared
+		mismatches = append(mismatches, slotMismatches...)
+	}
+
+	return comparedBlocks, mismatches, nil
+}
+
+// compareSlotBlocks compares the blocks of slot in both stores, returning the number of blocks
+// compared and the mismatched ones.
+func compareSlotBlocks(slot uint64, referenceBlocks, currentBlocks []*mergedBlock, ignoredFields []string) (int, []*blockMismatch) {
+	var mismatches []*blockMismatch
+	different := func(reference, current *mergedBlock) {
+		if diffs := fetcher.FilterDiffs(diffMergedBlocks(reference, current), ignoredFields); len(diffs) > 0 {
+			mismatches = append(mismatches, &blockMismatch{Slot: slot, BlockID: reference.block.Id, Kind: mismatchDifferent, Diffs: diffs})
+		}
+	}
+
+	currentByID := map[string]*mergedBlock{}
+	for _, block := range currentBlocks {
+		currentByID[block.block.Id] = block
+	}
+
+	var unmatchedReference []*mergedBlock
+	for _, reference := range referenceBlocks {
+		current, found := currentByID[reference.block.Id]
+		if !found {
+			unmatchedReference = append(unmatchedReference, reference)
+			continue
+		}
+		delete(currentByID, reference.block.Id)
+		different(reference, current)
+	}
+
+	var unmatchedCurrent []*mergedBlock
+	for _, current := range currentBlocks {
+		if _, found := currentByID[current.block.Id]; found {
+			unmatchedCurrent = append(unmatchedCurrent, current)
+		}
+	}
+
+	compared := len(referenceBlocks) + len(unmatchedCurrent)
+	if len(unmatchedReference) == 1 && len(unmatchedCurrent) == 1 {
+		// The same block with a different id
+		different(unmatchedReference[0], unmatchedCurrent[0])
+		return compared - 1, mismatches
+	}
+
+	for _, reference := range unmatchedReference {
+		mismatches = append(mismatches, &blockMismatch{Slot: slot, BlockID: reference.block.Id, Kind: mismatchMissing})
+	}
+	for _, current := range unmatchedCurrent {
+		mismatches = append(mismatches, &blockMismatch{Slot: slot, BlockID: current.block.Id, Kind: mismatchExtra})
+	}
+	return compared, mismatches
+}
+
+type mergedBlock struct {
+	block    *pbbstream.Block
+	solBlock *pbsol.Block
+}
+
+// readMergedBlocksBundleBlocks returns the blocks of the bundle starting at base by slot, a
+// store holding forked blocks having many of them for a slot, none when the bundle does not
+// exist.
+func readMergedBlocksBundleBlocks(ctx context.Context, store dstore.Store, base uint64) (map[uint64][]*mergedBlock, error) {
+	blocks := map[uint64][]*mergedBlock{}
+	filename := fmt.Sprintf("%010d", base)
+	exists, err := store.FileExists(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("checking merged blocks bundle %s: %w", filename, err)
+	}
+	if !exists {
+		return blocks, nil
+	}
+
+	err = readMergedBlocksBundle(ctx, store, filename, base, base+100, func(block *pbbstream.Block, solBlock *pbsol.Block) error {
+		fetcher.NormalizeForCompare(solBlock)
+		blocks[block.Number] = append(blocks[block.Number], &mergedBlock{block: block, solBlock: solBlock})
+		return nil
+	})
+	return blocks, err
+}
+
+// diffMergedBlocks returns the differences of the solana blocks, along with the ones of their
+// bstream header fields, prefixed by `bstream.`.
+func diffMergedBlocks(reference, current *mergedBlock) []*fetcher.FieldDiff {
+	referenceHeader := proto.Clone(reference.block).(*pbbstream.Block)
+	currentHeader := proto.Clone(current.block).(*pbbstream.Block)
+	referenceHeader.Payload, currentHeader.Payload = nil, nil
+
+	diffs := fetcher.DiffMessages(referenceHeader, currentHeader)
+	for _, diff := range diffs {
+		diff.Path = "bstream." + diff.Path
+	}
+	return append(diffs, fetcher.DiffMessages(reference.solBlock, current.solBlock)...)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/dstore"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+// withBlockHeight returns a copy of block whose solana block has height.
+func withBlockHeight(t *testing.T, block *pbbstream.Block, height uint64) *pbbstream.Block {
+	solBlock := &pbsol.Block{}
+	require.NoError(t, block.Payload.UnmarshalTo(solBlock))
+	solBlock.BlockHeight = &pbsol.BlockHeight{BlockHeight: height}
+
+	out := upgraderBlock(t, block.Id, solBlock.ParentSlot, block.ParentId)
+	require.NoError(t, out.Payload.MarshalFrom(solBlock))
+	return out
+}
+
+func Test_CompareMergedBlocks(t *testing.T) {
+	ctx := context.Background()
+	referenceStore, currentStore := dstore.NewMockStore(nil), dstore.NewMockStore(nil)
+
+	// Bundle 100 holds a forked block at slot 104 in the reference store
+	reference := linearBlocks(t, 99, 100, 105)
+	reference = append(reference, upgraderBlock(t, "104b", 103, "103"))
+	writeBundle(t, referenceStore, 100, reference)
+	writeBundle(t, currentStore, 100, []*pbbstream.Block{
+		reference[0],
+		withBlockHeight(t, reference[1], 42),
+		reference[3],
+		reference[4],
+		upgraderBlock(t, "105", 104, "104"),
+	})
+
+	// Bundle 200 is missing from the current store, bundle 300 from both
+	writeBundle(t, referenceStore, 200, linearBlocks(t, 104, 200, 202))
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	report, err := loadCompareReport(reportPath, "reference", "current")
+	require.NoError(t, err)
+	require.Equal(t, []uint64{100, 200}, report.pending(100, 300))
+
+	comparer := &mergedBlocksComparer{
+		referenceStore: referenceStore,
+		currentStore:   currentStore,
+		report:         report,
+		reportPath:     reportPath,
+		logger:         zap.NewNop(),
+	}
+	require.NoError(t, comparer.compare(ctx, report.pending(100, 300), 1))
+
+	type mismatch struct {
+		slot    uint64
+		blockID string
+		kind    string
+	}
+	var mismatches []mismatch
+	for _, m := range report.Mismatches {
+		mismatches = append(mismatches, mismatch{m.Slot, m.BlockID, m.Kind})
+	}
+	require.Equal(t, []mismatch{
+		{101, "101", mismatchDifferent},
+		{102, "102", mismatchMissing},
+		{104, "104b", mismatchMissing},
+		{105, "105", mismatchExtra},
+		{200, "200", mismatchMissing},
+		{201, "201", mismatchMissing},
+	}, mismatches)
+	require.Equal(t, "block_height", report.Mismatches[0].Diffs[0].Path)
+	require.Equal(t, 9, report.Summary.ComparedBlocks)
+	require.Equal(t, map[string]int{mismatchMissing: 4, mismatchExtra: 1, mismatchDifferent: 1}, report.Summary.Kinds)
+
+	// A new run resumes from the saved report, only comparing the bundles left
+	resumed, err := loadCompareReport(reportPath, "reference", "current")
+	require.NoError(t, err)
+	require.Equal(t, []uint64{100, 200}, resumed.ComparedBundles)
+	require.Equal(t, report.Summary, resumed.Summary)
+	require.Equal(t, []uint64{300}, resumed.pending(100, 400))
+
+	comparer.report = resumed
+	require.NoError(t, comparer.compare(ctx, resumed.pending(100, 400), 1))
+	require.Equal(t, []uint64{100, 200, 300}, resumed.ComparedBundles)
+	require.Equal(t, 9, resumed.Summary.ComparedBlocks, "bundle 300 is missing from both stores")
+
+	_, err = loadCompareReport(reportPath, "reference", "other")
+	require.EqualError(t, err, "report "+reportPath+" compares reference to current, not reference to other, use another --report")
+}
+
+func Test_CompareSlotBlocks(t *testing.T) {
+	block := func(id string) *mergedBlock {
+		b := upgraderBlock(t, id, 9, "9")
+		solBlock := &pbsol.Block{}
+		require.NoError(t, b.Payload.UnmarshalTo(solBlock))
+		return &mergedBlock{block: b, solBlock: solBlock}
+	}
+
+	// A single block with another id on each side is the same slot's block, differing
+	compared, mismatches := compareSlotBlocks(10, []*mergedBlock{block("10")}, []*mergedBlock{block("10x")}, nil)
+	require.Equal(t, 1, compared)
+	require.Len(t, mismatches, 1)
+	require.Equal(t, mismatchDifferent, mismatches[0].Kind)
+	require.Equal(t, "bstream.id", mismatches[0].Diffs[0].Path)
+
+	compared, mismatches = compareSlotBlocks(10, []*mergedBlock{block("10"), block("10b")}, []*mergedBlock{block("10")}, nil)
+	require.Equal(t, 2, compared)
+	require.Len(t, mismatches, 1)
+	require.Equal(t, &blockMismatch{Slot: 10, BlockID: "10b", Kind: mismatchMissing}, mismatches[0])
+}
@@ -0,0 +1,85 @@
+package ledger
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-core/blockpoller"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+func NewFetchCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ledger <ledger-dir> <start> <stop>",
+		Short: "fetch the rooted blocks of [start, stop] from a validator's RocksDB ledger directory",
+		Long: "Fetch the rooted blocks of [start, stop] from a validator's RocksDB ledger directory, or a warehouse ledger " +
+			"archive once extracted. Slots that are not rooted are skipped. The parent of start must be in the ledger too, " +
+			"its entries giving start's previous blockhash. firesol must be built with the rocksdb build tag.",
+		Args: cobra.ExactArgs(3),
+		RunE: fetchRunE(logger, tracer),
+	}
+
+	patches.AddFlags(cmd)
+
+	return cmd
+}
+
+func fetchRunE(logger *zap.Logger, tracer logging.Tracer) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		ctx := cmd.Context()
+
+		ledgerDir := args[0]
+		start, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing start block num: %w", err)
+		}
+		stop, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing stop block num: %w", err)
+		}
+		if stop < start {
+			return fmt.Errorf("stop block %d is before start block %d", stop, start)
+		}
+
+		logger.Info(
+			"launching firehose-solana ledger fetcher",
+			zap.String("ledger_dir", ledgerDir),
+			zap.Uint64("start_block", start),
+			zap.Uint64("stop_block", stop),
+		)
+
+		chainPatches, err := patches.LoadFromFlags(cmd, logger)
+		if err != nil {
+			return err
+		}
+
+		db, err := fetcher.OpenRocksDBLedger(ledgerDir)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		handler := blockpoller.NewFireBlockHandler("type.googleapis.com/sf.solana.type.v1.Block")
+		handler.Init()
+
+		reader := fetcher.NewLedgerReader(db, chainPatches, logger)
+		err = reader.Read(ctx, start, stop, func(blk *pbsol.Block) error {
+			block, err := fetcher.BstreamBlockFromBlock(blk)
+			if err != nil {
+				return fmt.Errorf("converting block: %w", err)
+			}
+			return handler.Handle(block)
+		})
+		if err != nil {
+			return fmt.Errorf("reading ledger: %w", err)
+		}
+
+		return nil
+	}
+}
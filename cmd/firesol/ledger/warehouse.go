@@ -0,0 +1,111 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/patches"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+func NewWarehouseBackfillCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "warehouse-backfill <archives-store> <destination> <start> <stop>",
+		Short: "write merged blocks for [start, stop) read from the RocksDB ledger archives of a warehouse store",
+		Long: "Write merged blocks for [start, stop) read from the ledger archives of a warehouse store, laid out as " +
+			"<first-slot>/rocksdb.tar.bz2 with an optional <first-slot>/bounds.txt. Archives are streamed and extracted one " +
+			"at a time to --work-dir, then removed once read. The range is rounded to 100 blocks bundle boundaries. " +
+			"firesol must be built with the rocksdb build tag.",
+		Args: cobra.ExactArgs(4),
+		RunE: warehouseBackfillRunE(logger, tracer),
+	}
+
+	patches.AddFlags(cmd)
+	cmd.Flags().String("work-dir", os.TempDir(), "Directory where archives are extracted, it needs room for one extracted ledger")
+
+	return cmd
+}
+
+func warehouseBackfillRunE(logger *zap.Logger, tracer logging.Tracer) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		archivesStore, err := dstore.NewStore(args[0], "", "", false)
+		if err != nil {
+			return fmt.Errorf("reading archives store: %w", err)
+		}
+
+		destination := args[1]
+		destStore, err := dstore.NewStore(destination, "dbin.zst", "zstd", true)
+		if err != nil {
+			return fmt.Errorf("reading destination store: %w", err)
+		}
+
+		start, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing start block num: %w", err)
+		}
+		stop, err := strconv.ParseUint(args[3], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing stop block num: %w", err)
+		}
+
+		start = start - start%100
+		if stop%100 != 0 {
+			stop = stop - stop%100 + 100
+		}
+		if stop <= start {
+			return fmt.Errorf("empty range [%d, %d)", start, stop)
+		}
+
+		workDir := sflags.MustGetString(cmd, "work-dir")
+		logger.Info("starting warehouse backfill",
+			zap.String("archives", args[0]),
+			zap.String("destination", destination),
+			zap.String("work_dir", workDir),
+			zap.Uint64("start", start),
+			zap.Uint64("stop", stop),
+		)
+
+		chainPatches, err := patches.LoadFromFlags(cmd, logger)
+		if err != nil {
+			return err
+		}
+
+		writer := &firecore.MergedBlocksWriter{
+			Cmd:          cmd,
+			Store:        destStore,
+			LowBlockNum:  start,
+			StopBlockNum: stop,
+			Logger:       logger,
+		}
+
+		reader := fetcher.NewWarehouseReader(archivesStore, workDir, fetcher.OpenRocksDBLedger, chainPatches, logger)
+		err = reader.Read(ctx, start, func(block *pbsol.Block) error {
+			blk, err := fetcher.BstreamBlockFromBlock(block)
+			if err != nil {
+				return fmt.Errorf("converting block %d: %w", block.Slot, err)
+			}
+			return writer.ProcessBlock(blk, nil)
+		})
+		if errors.Is(err, io.EOF) {
+			logger.Info("warehouse backfill completed")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("backfilling: %w", err)
+		}
+
+		return fmt.Errorf("warehouse archives end before stop block %d, last bundle would be incomplete", stop)
+	}
+}
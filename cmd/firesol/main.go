@@ -7,6 +7,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/firehose-core/cmd/tools"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/bigtable"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/ledger"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/patches"
 	"github.com/streamingfast/firehose-solana/cmd/firesol/rpc"
 	"github.com/streamingfast/logging"
 	"go.uber.org/zap"
@@ -24,6 +27,13 @@ func init() {
 
 	rootCmd.AddCommand(tools.ToolsCmd)
 	tools.ToolsCmd.AddCommand(NewUpgradeCmd(logger, tracer))
+	tools.ToolsCmd.AddCommand(bigtable.NewBackfillCmd(logger, tracer))
+	tools.ToolsCmd.AddCommand(ledger.NewWarehouseBackfillCmd(logger, tracer))
+	tools.ToolsCmd.AddCommand(patches.NewToolsCmd(logger, tracer))
+	tools.ToolsCmd.AddCommand(NewTrxErrorAuditCmd(logger, tracer))
+	tools.ToolsCmd.AddCommand(rpc.NewRecordCmd(logger, tracer))
+	tools.ToolsCmd.AddCommand(bigtable.NewCompareSourcesCmd(logger, tracer))
+	tools.ToolsCmd.AddCommand(NewCompareMergedBlocksCmd(logger, tracer))
 }
 
 func main() {
@@ -42,5 +52,8 @@ func newFetchCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
 	}
 	time.Now().UnixMilli()
 	cmd.AddCommand(rpc.NewFetchCmd(logger, tracer))
+	cmd.AddCommand(bigtable.NewFetchCmd(logger, tracer))
+	cmd.AddCommand(rpc.NewGeyserFetchCmd(logger, tracer))
+	cmd.AddCommand(ledger.NewFetchCmd(logger, tracer))
 	return cmd
 }
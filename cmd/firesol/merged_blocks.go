@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/streamingfast/bstream"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/dstore"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+)
+
+// readMergedBlocks calls processBlock with each block of [start, stop) found in the merged blocks
+// bundles of store, in order. Missing bundles are not an error.
+func readMergedBlocks(ctx context.Context, store dstore.Store, start, stop uint64, processBlock func(block *pbbstream.Block, solBlock *pbsol.Block) error) error {
+	base := start - start%100
+	err := store.WalkFrom(ctx, "", fmt.Sprintf("%010d", base), func(filename string) error {
+		var bundleBase uint64
+		if _, err := fmt.Sscanf(filename, "%d", &bundleBase); err != nil {
+			return nil
+		}
+		if bundleBase >= stop {
+			return dstore.StopIteration
+		}
+		return readMergedBlocksBundle(ctx, store, filename, start, stop, processBlock)
+	})
+	if err != nil && !errors.Is(err, dstore.StopIteration) {
+		return err
+	}
+	return nil
+}
+
+func readMergedBlocksBundle(ctx context.Context, store dstore.Store, filename string, start, stop uint64, processBlock func(block *pbbstream.Block, solBlock *pbsol.Block) error) error {
+	reader, err := store.OpenObject(ctx, filename)
+	if err != nil {
+		return fmt.Errorf("opening merged blocks bundle %s: %w", filename, err)
+	}
+	defer reader.Close()
+
+	blockReader, err := bstream.NewDBinBlockReader(reader)
+	if err != nil {
+		return fmt.Errorf("reading merged blocks bundle %s: %w", filename, err)
+	}
+
+	for {
+		block, err := blockReader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading block from merged blocks bundle %s: %w", filename, err)
+		}
+		if block.Number < start || block.Number >= stop {
+			continue
+		}
+
+		solBlock := &pbsol.Block{}
+		if err := block.Payload.UnmarshalTo(solBlock); err != nil {
+			return fmt.Errorf("unmarshaling solana block %d: %w", block.Number, err)
+		}
+		if err := processBlock(block, solBlock); err != nil {
+			return err
+		}
+	}
+}
+
+// writeMergedBlocksBundle writes blocks as the merged blocks bundle starting at base.
+func writeMergedBlocksBundle(ctx context.Context, store dstore.Store, base uint64, blocks []*pbbstream.Block) error {
+	filename := fmt.Sprintf("%010d", base)
+	if len(blocks) == 0 {
+		return fmt.Errorf("no blocks to write to merged blocks bundle %s", filename)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		blockWriter, err := bstream.NewDBinBlockWriter(pw)
+		for i := 0; err == nil && i < len(blocks); i++ {
+			err = blockWriter.Write(blocks[i])
+		}
+		pw.CloseWithError(err)
+	}()
+
+	if err := store.WriteObject(ctx, filename, pr); err != nil {
+		pr.CloseWithError(err)
+		return fmt.Errorf("writing merged blocks bundle %s: %w", filename, err)
+	}
+	return nil
+}
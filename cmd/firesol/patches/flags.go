@@ -0,0 +1,32 @@
+package patches
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/firehose-solana/patches"
+	"go.uber.org/zap"
+)
+
+// AddFlags adds the flags selecting the chain patches applied to fetched blocks.
+func AddFlags(cmd *cobra.Command) {
+	cmd.Flags().String("network", patches.DefaultNetwork, fmt.Sprintf("Network whose embedded chain patches are used, one of %v", patches.Networks()))
+	cmd.Flags().String("patches-file", "", "If non-empty, chain patches are read from this YAML file instead of the embedded ones of --network")
+}
+
+// LoadFromFlags loads the chain patches selected by the flags added with AddFlags.
+func LoadFromFlags(cmd *cobra.Command, logger *zap.Logger) (*patches.Registry, error) {
+	registry, err := patches.LoadOrDefault(sflags.MustGetString(cmd, "patches-file"), sflags.MustGetString(cmd, "network"))
+	if err != nil {
+		return nil, fmt.Errorf("loading chain patches: %w", err)
+	}
+
+	logger.Info("loaded chain patches",
+		zap.String("network", registry.Network),
+		zap.Int("revision", registry.Revision),
+		zap.Int("skipped_slots_ranges", len(registry.SkippedSlots)),
+		zap.Int("previous_blockhashes", len(registry.PreviousBlockhashes)),
+	)
+	return registry, nil
+}
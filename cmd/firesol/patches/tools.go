@@ -0,0 +1,102 @@
+package patches
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-solana/patches"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+func NewToolsCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "patches",
+		Short: "inspect the chain patches applied to fetched blocks",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list the chain patches selected by --network and --patches-file",
+		Args:  cobra.NoArgs,
+		RunE:  listRunE(logger),
+	}
+	AddFlags(listCmd)
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify <merged-blocks-store>",
+		Short: "check each chain patch against the merged blocks of a store",
+		Long: "Check each chain patch against the merged blocks of a store: skipped slots must have no block and each patched " +
+			"block must carry the patched previous blockhash, which must be the hash of its parent block. Patches without a " +
+			"slot, or whose blocks are not in the store, are reported as unverified.",
+		Args: cobra.ExactArgs(1),
+		RunE: verifyRunE(logger),
+	}
+	AddFlags(verifyCmd)
+
+	cmd.AddCommand(listCmd, verifyCmd)
+	return cmd
+}
+
+func listRunE(logger *zap.Logger) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		registry, err := LoadFromFlags(cmd, logger)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Network %s, revision %d\n", registry.Network, registry.Revision)
+
+		fmt.Printf("\nSkipped slots (%d)\n", len(registry.SkippedSlots))
+		for _, skipped := range registry.SkippedSlots {
+			fmt.Printf("  [%d, %d] %s\n", skipped.First, skipped.Last, skipped.Reason)
+		}
+
+		fmt.Printf("\nPrevious blockhashes (%d)\n", len(registry.PreviousBlockhashes))
+		for _, patch := range registry.PreviousBlockhashes {
+			slot := "unknown slot"
+			if patch.Slot != 0 {
+				slot = fmt.Sprintf("slot %d", patch.Slot)
+			}
+			fmt.Printf("  %s -> %s (%s)\n", patch.Blockhash, patch.PreviousBlockhash, slot)
+		}
+		return nil
+	}
+}
+
+func verifyRunE(logger *zap.Logger) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		registry, err := LoadFromFlags(cmd, logger)
+		if err != nil {
+			return err
+		}
+
+		store, err := dstore.NewDBinStore(args[0])
+		if err != nil {
+			return fmt.Errorf("reading merged blocks store: %w", err)
+		}
+
+		results, err := registry.Verify(cmd.Context(), store)
+		if err != nil {
+			return fmt.Errorf("verifying patches: %w", err)
+		}
+
+		counts := map[patches.VerifyStatus]int{}
+		for _, result := range results {
+			counts[result.Status]++
+			line := fmt.Sprintf("%-10s %s", result.Status, result.Patch)
+			if result.Detail != "" {
+				line += ": " + result.Detail
+			}
+			fmt.Println(line)
+		}
+
+		fmt.Printf("\n%d ok, %d failed, %d unverified\n", counts[patches.VerifyOK], counts[patches.VerifyFailed], counts[patches.VerifyUnverified])
+		if counts[patches.VerifyFailed] > 0 {
+			return fmt.Errorf("%d patch(es) failed verification", counts[patches.VerifyFailed])
+		}
+		return nil
+	}
+}
@@ -2,16 +2,18 @@ package rpc
 
 import (
 	"fmt"
-	firecoreRPC "github.com/streamingfast/firehose-core/rpc"
+	"net/url"
 	"strconv"
 	"time"
 
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dmetrics"
 	firecore "github.com/streamingfast/firehose-core"
 	"github.com/streamingfast/firehose-core/blockpoller"
 	"github.com/streamingfast/firehose-solana/block/fetcher"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/patches"
 	"github.com/streamingfast/logging"
 	"go.uber.org/zap"
 )
@@ -24,11 +26,28 @@ func NewFetchCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
 		RunE:  fetchRunE(logger, tracer),
 	}
 
-	cmd.Flags().StringArray("endpoints", []string{}, "List of endpoints to use to fetch different method calls")
+	cmd.Flags().StringArray("endpoints", []string{}, "List of endpoints used for every method call, unless overridden by --slot-endpoints or --block-endpoints")
+	cmd.Flags().StringArray("slot-endpoints", []string{}, "List of endpoints used to track the latest confirmed and finalized slots (getSlot), defaults to --endpoints")
+	cmd.Flags().StringArray("block-endpoints", []string{}, "List of endpoints used to fetch blocks (getBlock), defaults to --endpoints")
 	cmd.Flags().String("state-dir", "/data/poller", "interval between fetch")
 	cmd.Flags().Duration("interval-between-fetch", 0, "interval between fetch")
 	cmd.Flags().Duration("latest-block-retry-interval", time.Second, "interval between fetch")
 	cmd.Flags().Int("block-fetch-batch-size", 10, "Number of blocks to fetch in a single batch")
+	cmd.Flags().String("ws-endpoint", "", "If non-empty, websocket endpoint whose slotsUpdatesSubscribe optimistic confirmations and rootSubscribe notifications drive head tracking instead of polling, polling is used while it is disconnected")
+	cmd.Flags().Duration("ws-reconnect-delay", 5*time.Second, "Delay before reconnecting to --ws-endpoint after the connection is lost")
+	cmd.Flags().Duration("endpoint-health-check-interval", 5*time.Second, "Interval between checks of every slot endpoint's latest slot, used to detect lagging endpoints, and between logs of endpoints health (0 disables). Block endpoints are not checked, their health only comes from their getBlock calls")
+	cmd.Flags().Uint64("endpoint-max-slot-lag", fetcher.DefaultHealthConfig.MaxSlotLag, "Number of slots a slot endpoint can be behind the most advanced one before being avoided")
+	cmd.Flags().Int("endpoint-max-consecutive-errors", fetcher.DefaultHealthConfig.MaxConsecutiveErrors, "Number of failed requests in a row after which an endpoint is benched")
+	cmd.Flags().Duration("endpoint-bench-duration", fetcher.DefaultHealthConfig.BenchDuration, "How long a failing endpoint is avoided")
+	cmd.Flags().String("metrics-listen-addr", "", "If non-empty, serve Prometheus metrics, including endpoints health, on this address")
+	cmd.Flags().Int("fetch-max-attempts", fetcher.DefaultRetryConfig.MaxAttempts, "Number of times all block endpoints are tried for a slot before the poller stops with an error, 0 retries forever")
+	cmd.Flags().Duration("fetch-initial-backoff", fetcher.DefaultRetryConfig.InitialBackoff, "Delay before retrying a slot the first time, doubled on each following attempt")
+	cmd.Flags().Duration("fetch-max-backoff", fetcher.DefaultRetryConfig.MaxBackoff, "Maximum delay between two attempts at fetching a slot")
+	cmd.Flags().String("commitment", string(fetcher.DefaultRequestConfig.Commitment), "Commitment blocks are fetched at, 'confirmed' or 'finalized', the latter only fetching slots once they are finalized")
+	cmd.Flags().Uint64("max-supported-transaction-version", fetcher.DefaultRequestConfig.MaxSupportedTransactionVersion, "Highest transaction version requested, the poller stops with an error on blocks holding a transaction of a higher version")
+	patches.AddFlags(cmd)
+	cmd.Flags().String("conversion-policy", string(fetcher.ConversionPolicyFail), "What to do with block values that can't be converted, like a variant added by a validator upgrade: 'fail' stops the poller, 'unknown' keeps the block with an unknown marker in place of the value, kept as received in the block's unknown values")
+	cmd.Flags().Int("prefetch-window", 10, "Number of upcoming confirmed slots fetched concurrently, spread across endpoints, when a block is requested (1 disables prefetching)")
 
 	return cmd
 }
@@ -52,29 +71,117 @@ func fetchRunE(logger *zap.Logger, tracer logging.Tracer) firecore.CommandExecut
 			zap.Uint64("first_streamable_block", startBlock),
 			zap.Duration("interval_between_fetch", fetchInterval),
 			zap.Duration("latest_block_retry_interval", sflags.MustGetDuration(cmd, "latest-block-retry-interval")),
+			zap.String("commitment", sflags.MustGetString(cmd, "commitment")),
 		)
 
-		rpcEndpoints := sflags.MustGetStringArray(cmd, "endpoints")
-		rpcClients := firecoreRPC.NewClients[*rpc.Client]()
-		for _, rpcEndpoint := range rpcEndpoints {
-			client := rpc.New(rpcEndpoint)
-			rpcClients.Add(client)
+		healthConfig := fetcher.HealthConfig{
+			MaxSlotLag:           sflags.MustGetUint64(cmd, "endpoint-max-slot-lag"),
+			MaxConsecutiveErrors: sflags.MustGetInt(cmd, "endpoint-max-consecutive-errors"),
+			BenchDuration:        sflags.MustGetDuration(cmd, "endpoint-bench-duration"),
+		}
+
+		defaultEndpoints := sflags.MustGetStringArray(cmd, "endpoints")
+		slotClients, err := newRPCClients("slot", endpointsFlag(cmd, "slot-endpoints", defaultEndpoints), healthConfig)
+		if err != nil {
+			return err
+		}
+		blockClients, err := newRPCClients("block", endpointsFlag(cmd, "block-endpoints", defaultEndpoints), healthConfig)
+		if err != nil {
+			return err
+		}
+
+		if addr := sflags.MustGetString(cmd, "metrics-listen-addr"); addr != "" {
+			fetcher.RegisterMetrics()
+			go dmetrics.Serve(addr)
+		}
+
+		if interval := sflags.MustGetDuration(cmd, "endpoint-health-check-interval"); interval > 0 {
+			go slotClients.RunHealthChecks(ctx, interval, logger)
+			// Block endpoints can be paid archive nodes, polling their slot would be billed for
+			// nothing as they are picked from their getBlock latency and errors only
+			go blockClients.RunHealthLogs(ctx, interval, logger)
+		}
+
+		retryConfig := fetcher.RetryConfig{
+			MaxAttempts:    sflags.MustGetInt(cmd, "fetch-max-attempts"),
+			InitialBackoff: sflags.MustGetDuration(cmd, "fetch-initial-backoff"),
+			MaxBackoff:     sflags.MustGetDuration(cmd, "fetch-max-backoff"),
+		}
+		if retryConfig.MaxAttempts < 0 || retryConfig.InitialBackoff <= 0 || retryConfig.MaxBackoff < retryConfig.InitialBackoff {
+			return fmt.Errorf("invalid retry configuration, --fetch-max-attempts must be positive or 0 and --fetch-max-backoff must be greater than a positive --fetch-initial-backoff")
+		}
+
+		requestConfig := fetcher.RequestConfig{
+			Commitment:                     rpc.CommitmentType(sflags.MustGetString(cmd, "commitment")),
+			MaxSupportedTransactionVersion: sflags.MustGetUint64(cmd, "max-supported-transaction-version"),
+		}
+		if err := requestConfig.Validate(); err != nil {
+			return fmt.Errorf("invalid --commitment: %w", err)
+		}
+
+		conversionPolicy, err := fetcher.ParseConversionPolicy(sflags.MustGetString(cmd, "conversion-policy"))
+		if err != nil {
+			return err
+		}
+
+		chainPatches, err := patches.LoadFromFlags(cmd, logger)
+		if err != nil {
+			return err
 		}
 
 		latestBlockRetryInterval := sflags.MustGetDuration(cmd, "latest-block-retry-interval")
 
+		rpcFetcher := fetcher.NewRPC(slotClients, blockClients, fetchInterval, latestBlockRetryInterval, sflags.MustGetInt(cmd, "prefetch-window"), retryConfig, requestConfig, conversionPolicy, chainPatches, logger)
+		defer rpcFetcher.Close()
+		if wsEndpoint := sflags.MustGetString(cmd, "ws-endpoint"); wsEndpoint != "" {
+			headTracker := fetcher.NewWSHeadTracker(wsEndpoint, sflags.MustGetDuration(cmd, "ws-reconnect-delay"), logger.With(zap.String("ws_endpoint", endpointName(wsEndpoint))))
+			go headTracker.Run(ctx)
+			rpcFetcher.SetHeadTracker(headTracker)
+		}
+
 		poller := blockpoller.New(
-			fetcher.NewRPC(rpcClients, fetchInterval, latestBlockRetryInterval, logger),
+			rpcFetcher,
 			blockpoller.NewFireBlockHandler("type.googleapis.com/sf.solana.type.v1.Block"),
 			blockpoller.WithStoringState(stateDir),
 			blockpoller.WithLogger(logger),
 		)
 
-		err = poller.Run(ctx, startBlock, sflags.MustGetInt(cmd, "block-fetch-batch-size"))
-		if err != nil {
-			return fmt.Errorf("running poller: %w", err)
+		return fetcher.RunPoller(ctx, poller, startBlock, sflags.MustGetInt(cmd, "block-fetch-batch-size"))
+	}
+}
+
+func endpointsFlag(cmd *cobra.Command, flagName string, defaultEndpoints []string) []string {
+	if endpoints := sflags.MustGetStringArray(cmd, flagName); len(endpoints) > 0 {
+		return endpoints
+	}
+	return defaultEndpoints
+}
+
+func newRPCClients(pool string, endpoints []string, healthConfig fetcher.HealthConfig) (*fetcher.RPCClients, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no %s endpoints configured, use --endpoints or --%s-endpoints", pool, pool)
+	}
+
+	clients := fetcher.NewRPCClientsWithHealthConfig(pool, healthConfig)
+	seenNames := map[string]bool{}
+	for i, endpoint := range endpoints {
+		name := endpointName(endpoint)
+		if seenNames[name] {
+			name = fmt.Sprintf("%s#%d", name, i)
 		}
+		seenNames[name] = true
+
+		clients.Add(name, rpc.New(endpoint))
+	}
+	return clients, nil
+}
 
-		return nil
+// endpointName identifies an endpoint in logs and errors without leaking credentials that
+// providers commonly embed in the URL path or query.
+func endpointName(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "<invalid endpoint>"
 	}
+	return u.Host
 }
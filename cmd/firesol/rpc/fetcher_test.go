@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+func Test_FetchCmdReturnsFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		id, _ := json.Marshal(req.ID)
+
+		switch req.Method {
+		case "getSlot":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":100}`, id)
+		case "getBlock":
+			slot := uint64(req.Params[0].(float64))
+			if slot > 10 {
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32001,"message":"Block %d cleaned up"}}`, id, slot)
+				return
+			}
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"blockhash":%q,"previousBlockhash":%q,"parentSlot":%d,"transactions":[],"rewards":[]}}`, id, solana.Hash{byte(slot)}, solana.Hash{byte(slot - 1)}, slot-1)
+		}
+	}))
+	defer server.Close()
+
+	// Slot 10 is polled, slot 11 fails for good in the poller's own fetching loop, which shuts
+	// the poller down instead of returning an error from Run
+	cmd := NewFetchCmd(zap.NewNop(), nil)
+	cmd.SetArgs([]string{"10",
+		"--endpoints", server.URL,
+		"--state-dir", t.TempDir(),
+		"--endpoint-health-check-interval", "0",
+		"--prefetch-window", "1",
+		"--block-fetch-batch-size", "1",
+	})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := cmd.ExecuteContext(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "running poller")
+	require.Contains(t, err.Error(), "giving up on slot 11")
+}
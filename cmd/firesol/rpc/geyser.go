@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-core/blockpoller"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/patches"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func NewGeyserFetchCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "geyser <first-streamable-block>",
+		Short: "fetch blocks from a Yellowstone Geyser gRPC endpoint, falling back to rpc endpoints for blocks not streamed",
+		Args:  cobra.ExactArgs(1),
+		RunE:  geyserFetchRunE(logger, tracer),
+	}
+
+	cmd.Flags().String("geyser-endpoint", "", "Yellowstone Geyser gRPC endpoint, as host:port")
+	cmd.Flags().String("geyser-x-token", "", "If non-empty, sent as the x-token header to authenticate with --geyser-endpoint")
+	cmd.Flags().Bool("geyser-plaintext", false, "Connect to --geyser-endpoint without TLS")
+	cmd.Flags().Int("geyser-max-message-size", 1024*1024*1024, "Maximum size in bytes of a message received from --geyser-endpoint, blocks are sent as a single message")
+	cmd.Flags().Duration("geyser-reconnect-delay", 5*time.Second, "Delay before subscribing again to --geyser-endpoint after the stream ends")
+	cmd.Flags().Int("geyser-buffer-size", 300, "Number of streamed blocks kept until requested, the oldest ones are fetched from --fallback-endpoints once evicted")
+	cmd.Flags().StringArray("fallback-endpoints", []string{}, "List of rpc endpoints used to fetch blocks not streamed by --geyser-endpoint, such as the ones preceding the subscription, none fails the poller on such blocks")
+	cmd.Flags().Int("fallback-prefetch-window", 10, "Number of upcoming confirmed slots fetched concurrently from --fallback-endpoints when a block is requested (1 disables prefetching)")
+	cmd.Flags().String("fallback-conversion-policy", string(fetcher.ConversionPolicyFail), "What to do with values of blocks fetched from --fallback-endpoints that can't be converted: 'fail' stops the poller, 'unknown' keeps the block with an unknown marker in place of the value, kept as received in the block's unknown values")
+	cmd.Flags().String("state-dir", "/data/poller", "directory where the poller persists its state to resume from")
+	cmd.Flags().Duration("latest-block-retry-interval", time.Second, "interval between checks of the latest slot of --fallback-endpoints")
+	cmd.Flags().Int("block-fetch-batch-size", 10, "Number of blocks to fetch in a single batch")
+	patches.AddFlags(cmd)
+
+	return cmd
+}
+
+func geyserFetchRunE(logger *zap.Logger, tracer logging.Tracer) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		ctx := cmd.Context()
+
+		stateDir := sflags.MustGetString(cmd, "state-dir")
+
+		startBlock, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to parse first streamable block %q: %w", args[0], err)
+		}
+
+		geyserEndpoint := sflags.MustGetString(cmd, "geyser-endpoint")
+		if geyserEndpoint == "" {
+			return fmt.Errorf("--geyser-endpoint is required")
+		}
+
+		logger.Info(
+			"launching firehose-solana geyser poller",
+			zap.String("state_dir", stateDir),
+			zap.Uint64("first_streamable_block", startBlock),
+			zap.String("geyser_endpoint", geyserEndpoint),
+		)
+
+		chainPatches, err := patches.LoadFromFlags(cmd, logger)
+		if err != nil {
+			return err
+		}
+
+		transportCredentials := credentials.NewTLS(&tls.Config{})
+		if sflags.MustGetBool(cmd, "geyser-plaintext") {
+			transportCredentials = insecure.NewCredentials()
+		}
+
+		conn, err := grpc.NewClient(geyserEndpoint,
+			grpc.WithTransportCredentials(transportCredentials),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(sflags.MustGetInt(cmd, "geyser-max-message-size"))),
+		)
+		if err != nil {
+			return fmt.Errorf("creating geyser client: %w", err)
+		}
+		defer conn.Close()
+
+		var fallback blockpoller.BlockFetcher
+		if endpoints := sflags.MustGetStringArray(cmd, "fallback-endpoints"); len(endpoints) > 0 {
+			clients, err := newRPCClients("fallback", endpoints, fetcher.DefaultHealthConfig)
+			if err != nil {
+				return err
+			}
+			conversionPolicy, err := fetcher.ParseConversionPolicy(sflags.MustGetString(cmd, "fallback-conversion-policy"))
+			if err != nil {
+				return err
+			}
+			rpcFetcher := fetcher.NewRPC(clients, clients, 0, sflags.MustGetDuration(cmd, "latest-block-retry-interval"), sflags.MustGetInt(cmd, "fallback-prefetch-window"), fetcher.DefaultRetryConfig, fetcher.DefaultRequestConfig, conversionPolicy, chainPatches, logger.With(zap.String("source", "fallback")))
+			defer rpcFetcher.Close()
+			fallback = rpcFetcher
+		}
+
+		geyserFetcher := fetcher.NewGeyser(conn, sflags.MustGetString(cmd, "geyser-x-token"), sflags.MustGetDuration(cmd, "geyser-reconnect-delay"), sflags.MustGetInt(cmd, "geyser-buffer-size"), fallback, chainPatches, logger)
+		go geyserFetcher.Run(ctx)
+
+		poller := blockpoller.New(
+			geyserFetcher,
+			blockpoller.NewFireBlockHandler("type.googleapis.com/sf.solana.type.v1.Block"),
+			blockpoller.WithStoringState(stateDir),
+			blockpoller.WithLogger(logger),
+		)
+
+		return fetcher.RunPoller(ctx, poller, startBlock, sflags.MustGetInt(cmd, "block-fetch-batch-size"))
+	}
+}
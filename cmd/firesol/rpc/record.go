@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	"github.com/streamingfast/firehose-solana/cmd/firesol/patches"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+func NewRecordCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record-rpc <endpoint> <output-file> <start> <stop>",
+		Short: "record the rpc exchanges of fetching [start, stop) slots, to be replayed by the fetcher tests",
+		Long: "Fetch the [start, stop) slots from endpoint the way 'firesol fetch rpc' does, one slot at a time, and write " +
+			"every getSlot and getBlock request made along with the endpoint's response, errors included, to output-file. " +
+			"The recording is replayed by fetcher.RPCReplayer, standing in for the endpoint in offline tests.",
+		Args: cobra.ExactArgs(4),
+		RunE: recordRunE(logger),
+	}
+
+	cmd.Flags().String("commitment", string(fetcher.DefaultRequestConfig.Commitment), "Commitment blocks are fetched at, 'confirmed' or 'finalized'")
+	cmd.Flags().Uint64("max-supported-transaction-version", fetcher.DefaultRequestConfig.MaxSupportedTransactionVersion, "Highest transaction version requested")
+	cmd.Flags().Int("fetch-max-attempts", 3, "Number of times a slot is tried before giving up on it, its failures being recorded too")
+	patches.AddFlags(cmd)
+
+	return cmd
+}
+
+func recordRunE(logger *zap.Logger) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		endpoint, outputFile := args[0], args[1]
+		start, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing start slot: %w", err)
+		}
+		stop, err := strconv.ParseUint(args[3], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing stop slot: %w", err)
+		}
+		if stop <= start {
+			return fmt.Errorf("stop slot %d must be greater than start slot %d", stop, start)
+		}
+
+		requestConfig := fetcher.RequestConfig{
+			Commitment:                     rpc.CommitmentType(sflags.MustGetString(cmd, "commitment")),
+			MaxSupportedTransactionVersion: sflags.MustGetUint64(cmd, "max-supported-transaction-version"),
+		}
+		if err := requestConfig.Validate(); err != nil {
+			return fmt.Errorf("invalid --commitment: %w", err)
+		}
+
+		chainPatches, err := patches.LoadFromFlags(cmd, logger)
+		if err != nil {
+			return err
+		}
+
+		recorder := fetcher.NewRPCRecorder(nil)
+		clients := fetcher.NewRPCClients("record")
+		clients.Add(endpointName(endpoint), rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(endpoint, &jsonrpc.RPCClientOpts{
+			HTTPClient: &http.Client{Transport: recorder},
+		})))
+
+		retryConfig := fetcher.DefaultRetryConfig
+		retryConfig.MaxAttempts = sflags.MustGetInt(cmd, "fetch-max-attempts")
+
+		// Fetching one slot at a time keeps the recording in the order slots are asked for
+		rpcFetcher := fetcher.NewRPC(clients, clients, 0, time.Second, 1, retryConfig, requestConfig, fetcher.ConversionPolicyUnknown, chainPatches, logger)
+		defer rpcFetcher.Close()
+
+		logger.Info("recording rpc exchanges", zap.String("endpoint", endpointName(endpoint)), zap.Uint64("start", start), zap.Uint64("stop", stop))
+		for slot := start; slot < stop; slot++ {
+			_, skip, err := rpcFetcher.Fetch(ctx, slot)
+			if err != nil {
+				// The failure is part of the recording, a replaying test expecting it
+				logger.Warn("fetching slot failed", zap.Uint64("slot", slot), zap.Error(err))
+				continue
+			}
+			logger.Info("fetched slot", zap.Uint64("slot", slot), zap.Bool("skipped", skip))
+		}
+
+		recording := recorder.Recording()
+		if err := recording.Save(outputFile); err != nil {
+			return fmt.Errorf("saving recording: %w", err)
+		}
+
+		logger.Info("recording saved", zap.String("output_file", outputFile), zap.Int("exchanges", len(recording.Exchanges)))
+		return nil
+	}
+}
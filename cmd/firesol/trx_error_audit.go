@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+func NewTrxErrorAuditCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "trx-error-audit <merged-blocks-store> <start> <stop>",
+		Short: "list the transaction errors of [start, stop) merged blocks that were kept as unknown variants, or can't be decoded",
+		Long: "List the transaction errors of the [start, stop) merged blocks that were kept as unknown variants, their JSON " +
+			"representation being encoded with a reserved code, or that can't be decoded, as well as the blocks' unknown values. " +
+			"A summary counts them by variant, telling which ones error.go should learn.",
+		Args: cobra.ExactArgs(3),
+		RunE: trxErrorAuditRunE(logger),
+	}
+}
+
+func trxErrorAuditRunE(logger *zap.Logger) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		store, err := dstore.NewDBinStore(args[0])
+		if err != nil {
+			return fmt.Errorf("reading merged blocks store: %w", err)
+		}
+
+		start, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing start block num: %w", err)
+		}
+		stop, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing stop block num: %w", err)
+		}
+
+		logger.Info("auditing transaction errors", zap.String("store", args[0]), zap.Uint64("start", start), zap.Uint64("stop", stop))
+
+		blockCount := 0
+		counts := map[string]int{}
+		err = readMergedBlocks(cmd.Context(), store, start, stop, func(_ *pbbstream.Block, block *pbsol.Block) error {
+			blockCount++
+			for _, finding := range fetcher.AuditTransactionErrors(block) {
+				fmt.Println(finding)
+				counts[fmt.Sprintf("%s %s", finding.Kind, finding.Variant)]++
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("reading merged blocks: %w", err)
+		}
+
+		keys := make([]string, 0, len(counts))
+		for key := range counts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fmt.Printf("\n%d blocks audited, %d kinds of findings\n", blockCount, len(keys))
+		for _, key := range keys {
+			fmt.Printf("  %6d %s\n", counts[key], key)
+		}
+		return nil
+	}
+}
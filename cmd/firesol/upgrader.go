@@ -1,37 +1,59 @@
 package main
 
 import (
-	"cmp"
 	"context"
 	"errors"
 	"fmt"
-	"io"
+	"os"
+	"os/signal"
+	"slices"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
 
+	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/spf13/cobra"
 	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
-	"github.com/streamingfast/bstream/stream"
+	"github.com/streamingfast/cli/sflags"
 	"github.com/streamingfast/dstore"
-	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	"github.com/streamingfast/firehose-solana/migrations"
 	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
 	"github.com/streamingfast/logging"
 	"go.uber.org/zap"
-	"golang.org/x/exp/slices"
+	"golang.org/x/sync/errgroup"
 )
 
 func NewUpgradeCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "upgrade-merged-blocks <source> <destination> <range>",
+		Use:   "upgrade-merged-blocks <source> <destination> <start> <stop>",
 		Short: "upgrade-merged-blocks from legacy to new format using anypb.Any as payload",
-		Args:  cobra.ExactArgs(4),
-		RunE:  getMergedBlockUpgrader(logger),
+		Long: "Upgrade the merged blocks bundles of [start, stop), start being rounded down and stop rounded up to 100 blocks " +
+			"bundle boundaries, applying the --migrations to each block in the listed order. Only the blocks of the final chain " +
+			"are kept, forked blocks being dropped. Bundles are upgraded concurrently, bundles already present in the destination " +
+			"store with the same migrations being skipped so that an interrupted upgrade resumes where it stopped, while the ones " +
+			"upgraded with other migrations are upgraded again. The migrations applied to a bundle are recorded as JSON in the '" + migrations.MetadataFolder + "' folder " +
+			"of the destination store. Known migrations are " + strings.Join(migrations.Names(), ", ") + ".",
+		Args: cobra.ExactArgs(4),
+		RunE: getMergedBlockUpgrader(logger),
 	}
 
+	cmd.Flags().StringSlice("migrations", defaultMigrations, "Comma separated migrations applied to each block, in order")
+	cmd.Flags().String("commission-rpc-endpoint", "", "RPC endpoint the backfill-commission migration gets rewards from, also adding backfill-commission to the default migrations when set")
+	cmd.Flags().Int("workers", 8, "Maximum number of bundles upgraded concurrently")
+
 	return cmd
 }
 
+var defaultMigrations = []string{"parent-num", "sort-rewards"}
+
 func getMergedBlockUpgrader(rootLog *zap.Logger) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
+		// Upgraded bundles are kept on interruption, the next run skipping them
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
 		source := args[0]
 		sourceStore, err := dstore.NewDBinStore(source)
 		if err != nil {
@@ -43,54 +65,295 @@ func getMergedBlockUpgrader(rootLog *zap.Logger) func(cmd *cobra.Command, args [
 		if err != nil {
 			return fmt.Errorf("reading destination store: %w", err)
 		}
+		metadataStore, err := migrations.NewMetadataStore(dest)
+		if err != nil {
+			return fmt.Errorf("reading destination metadata store: %w", err)
+		}
 
 		start, err := strconv.ParseUint(args[2], 10, 64)
 		if err != nil {
 			return fmt.Errorf("parsing start block num: %w", err)
 		}
-		stop, err := strconv.ParseUint(args[3], 10, 64)
+		stopBlock, err := strconv.ParseUint(args[3], 10, 64)
 		if err != nil {
 			return fmt.Errorf("parsing stop block num: %w", err)
 		}
 
-		rootLog.Info("starting block upgrader process", zap.Uint64("start", start), zap.Uint64("stop", stop), zap.String("source", source), zap.String("dest", dest))
-		writer := &firecore.MergedBlocksWriter{
-			Cmd:          cmd,
-			Store:        destStore,
-			LowBlockNum:  firecore.LowBoundary(start),
-			StopBlockNum: stop,
-			TweakBlock:   tweakBlock,
-			Logger:       rootLog,
+		workers := sflags.MustGetInt(cmd, "workers")
+		if workers <= 0 {
+			return fmt.Errorf("--workers must be greater than 0")
 		}
-		blockStream := stream.New(nil, sourceStore, nil, int64(start), writer, stream.WithFinalBlocksOnly())
 
-		err = blockStream.Run(context.Background())
-		if errors.Is(err, io.EOF) {
-			rootLog.Info("Complete!")
-			return nil
+		config := &migrations.Config{Logger: rootLog}
+		names := sflags.MustGetStringSlice(cmd, "migrations")
+		if endpoint := sflags.MustGetString(cmd, "commission-rpc-endpoint"); endpoint != "" {
+			config.CommissionBackfiller = fetcher.NewCommissionBackfiller(rpc.New(endpoint), rootLog)
+			if !cmd.Flags().Changed("migrations") {
+				// Commission is backfilled before rewards are sorted, as it was before migrations
+				names = []string{"parent-num", "backfill-commission", "sort-rewards"}
+			}
+		}
+
+		chain, err := migrations.NewChain(names, config)
+		if err != nil {
+			return err
+		}
+
+		// A single shard holds the whole range, rounded to bundle boundaries
+		shards := fetcher.SplitInShards(start, stopBlock, 1)
+		if len(shards) == 0 {
+			return fmt.Errorf("empty range [%d, %d)", start, stopBlock)
 		}
-		return err
+
+		rootLog.Info("starting block upgrader process",
+			zap.Uint64("start", shards[0].Start),
+			zap.Uint64("stop", shards[0].Stop),
+			zap.String("source", source),
+			zap.String("dest", dest),
+			zap.Strings("migrations", names),
+			zap.Int("workers", workers),
+		)
+
+		upgrader := &bundleUpgrader{
+			sourceStore:   sourceStore,
+			destStore:     destStore,
+			metadataStore: metadataStore,
+			chain:         chain,
+			logger:        rootLog,
+		}
+
+		upgraded, skipped, err := upgrader.upgradeRange(ctx, shards[0].Start, shards[0].Stop, workers)
+		if ctx.Err() != nil {
+			rootLog.Info("interrupted, upgraded bundles are kept and skipped when running again", zap.Int64("upgraded", upgraded), zap.Int64("skipped", skipped))
+			return ctx.Err()
+		}
+		if err != nil {
+			return err
+		}
+
+		rootLog.Info("Complete!", zap.Int64("upgraded", upgraded), zap.Int64("skipped", skipped))
+		return nil
 	}
 }
 
-func tweakBlock(block *pbbstream.Block) (*pbbstream.Block, error) {
-	b := &pbsol.Block{}
-	err := block.Payload.UnmarshalTo(b)
+// bundleUpgrader upgrades merged blocks bundles one at a time, any number of them being
+// upgraded concurrently.
+type bundleUpgrader struct {
+	sourceStore   dstore.Store
+	destStore     dstore.Store
+	metadataStore dstore.Store
+	chain         *migrations.Chain
+	logger        *zap.Logger
+}
+
+// upgradeRange upgrades the bundles of [start, stop), bounds being bundle boundaries, workers of
+// them at a time. It returns the number of bundles upgraded and skipped, stopping at the first
+// error or once ctx is done.
+func (u *bundleUpgrader) upgradeRange(ctx context.Context, start, stop uint64, workers int) (int64, int64, error) {
+	var upgraded, skipped atomic.Int64
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for base := start; base < stop; base += 100 {
+		if groupCtx.Err() != nil {
+			break
+		}
+
+		base := base
+		group.Go(func() error {
+			done, err := u.upgrade(groupCtx, base)
+			if err != nil {
+				return fmt.Errorf("upgrading bundle %010d: %w", base, err)
+			}
+			if done {
+				upgraded.Add(1)
+			} else {
+				skipped.Add(1)
+			}
+			return nil
+		})
+	}
+
+	err := group.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	return upgraded.Load(), skipped.Load(), err
+}
+
+// upgrade applies the migrations to the final blocks of the bundle starting at base, returning
+// false when the destination store already holds it upgraded with the same migrations. A
+// destination bundle written with other migrations, or without metadata, is upgraded again.
+// It is deleted first and its metadata is written before the bundle itself, a bundle present
+// in the destination store always having the metadata of the migrations it went through.
+func (u *bundleUpgrader) upgrade(ctx context.Context, base uint64) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	filename := fmt.Sprintf("%010d", base)
+	exists, err := u.destStore.FileExists(ctx, filename)
 	if err != nil {
-		return nil, fmt.Errorf("unmarshaling solana block %d: %w", block.Number, err)
+		return false, fmt.Errorf("checking destination bundle: %w", err)
+	}
+	if exists {
+		metadata, err := migrations.ReadBundleMetadata(ctx, u.metadataStore, base)
+		if err != nil {
+			return false, err
+		}
+		if metadata != nil && slices.Equal(metadata.Migrations, u.chain.Applied()) {
+			u.logger.Debug("bundle already upgraded, skipping", zap.Uint64("bundle", base))
+			return false, nil
+		}
+
+		var applied []migrations.Applied
+		if metadata != nil {
+			applied = metadata.Migrations
+		}
+		u.logger.Info("bundle upgraded with other migrations, upgrading it again", zap.Uint64("bundle", base), zap.Any("applied", applied), zap.Any("migrations", u.chain.Applied()))
+		if err := u.destStore.DeleteObject(ctx, filename); err != nil {
+			return false, fmt.Errorf("deleting destination bundle: %w", err)
+		}
 	}
 
-	block.ParentNum = b.ParentSlot
+	blocks, err := u.finalBlocks(ctx, base)
+	if err != nil {
+		return false, err
+	}
 
-	slices.SortFunc(b.Rewards, func(a, b *pbsol.Reward) int {
-		return cmp.Compare(a.Lamports, b.Lamports)
+	upgradedBlocks := make([]*pbbstream.Block, len(blocks))
+	for i, block := range blocks {
+		if upgradedBlocks[i], err = u.chain.ApplyDecoded(ctx, block.block, block.solBlock); err != nil {
+			return false, err
+		}
+	}
+
+	if err := migrations.WriteBundleMetadata(ctx, u.metadataStore, base, &migrations.BundleMetadata{Migrations: u.chain.Applied()}); err != nil {
+		return false, err
+	}
+	if err := writeMergedBlocksBundle(ctx, u.destStore, base, upgradedBlocks); err != nil {
+		return false, err
+	}
+
+	u.logger.Info("bundle upgraded", zap.Uint64("bundle", base), zap.Int("blocks", len(blocks)))
+	return true, nil
+}
+
+// finalBlocks returns the blocks of the source bundle starting at base that are on the final
+// chain, in order, forked blocks being dropped like the final blocks only stream used to. The
+// final chain is the one leading to the highest block of the next bundle, or of this bundle
+// when the next one is not in the source store yet, walking its blocks parents backward.
+func (u *bundleUpgrader) finalBlocks(ctx context.Context, base uint64) ([]*mergedBlock, error) {
+	var blocks []*mergedBlock
+	err := readMergedBlocksBundle(ctx, u.sourceStore, fmt.Sprintf("%010d", base), base, base+100, func(block *pbbstream.Block, solBlock *pbsol.Block) error {
+		blocks = append(blocks, &mergedBlock{block: block, solBlock: solBlock})
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no blocks in source bundle")
+	}
+
+	// Without fork, the first block of the next bundle linking to the last one is enough
+	linear := isLinear(blocks)
+	nextBlocks, err := u.readHeaders(ctx, base+100, linear)
+	if err != nil {
+		return nil, err
+	}
+	if linear && (len(nextBlocks) == 0 || nextBlocks[0].ParentId == blocks[len(blocks)-1].block.Id) {
+		return blocks, nil
+	}
+	if linear {
+		if nextBlocks, err = u.readHeaders(ctx, base+100, false); err != nil {
+			return nil, err
+		}
+	}
+
+	byID := map[string]*pbbstream.Block{}
+	candidates := make([]*pbbstream.Block, len(blocks))
+	for i, block := range blocks {
+		byID[block.block.Id] = block.block
+		candidates[i] = block.block
+	}
+	for _, block := range nextBlocks {
+		byID[block.Id] = block
+	}
+	if len(nextBlocks) > 0 {
+		candidates = nextBlocks
+	}
 
-	err = block.Payload.MarshalFrom(b)
+	var head *pbbstream.Block
+	tied := false
+	for _, block := range candidates {
+		switch {
+		case head == nil || block.Number > head.Number:
+			head, tied = block, false
+		case block.Number == head.Number && block.Id != head.Id:
+			tied = true
+		}
+	}
+	if tied {
+		return nil, fmt.Errorf("forks of block %d lead to the highest block, cannot tell which one is final", head.Number)
+	}
+
+	final := map[string]bool{}
+	for block := head; block != nil; block = byID[block.ParentId] {
+		if final[block.Id] {
+			return nil, fmt.Errorf("block %d (%s) is its own ancestor", block.Number, block.Id)
+		}
+		final[block.Id] = true
+	}
+
+	finalBlocks := make([]*mergedBlock, 0, len(blocks))
+	for _, block := range blocks {
+		if final[block.block.Id] {
+			finalBlocks = append(finalBlocks, block)
+			continue
+		}
+		u.logger.Info("dropping forked block", zap.Uint64("block_num", block.block.Number), zap.String("block_id", block.block.Id))
+	}
+	if len(finalBlocks) == 0 {
+		return nil, fmt.Errorf("no block of the source bundle leads to block %d (%s)", head.Number, head.Id)
+	}
+	return finalBlocks, nil
+}
 
+// readHeaders returns the number, id and parent id of the blocks of the source bundle starting
+// at base, only of its first block when firstOnly is set, and none when the bundle is not in
+// the source store.
+func (u *bundleUpgrader) readHeaders(ctx context.Context, base uint64, firstOnly bool) ([]*pbbstream.Block, error) {
+	filename := fmt.Sprintf("%010d", base)
+	exists, err := u.sourceStore.FileExists(ctx, filename)
 	if err != nil {
-		return nil, fmt.Errorf("marshaling solana block %d: %w", block.Number, err)
+		return nil, fmt.Errorf("checking source bundle %s: %w", filename, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	var headers []*pbbstream.Block
+	err = readMergedBlocksBundle(ctx, u.sourceStore, filename, base, base+100, func(block *pbbstream.Block, _ *pbsol.Block) error {
+		headers = append(headers, &pbbstream.Block{Number: block.Number, Id: block.Id, ParentId: block.ParentId})
+		if firstOnly {
+			return errStopReading
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopReading) {
+		return nil, err
 	}
+	return headers, nil
+}
+
+var errStopReading = errors.New("stop reading")
 
-	return block, nil
+// isLinear tells if each block of blocks is the child of the one before it.
+func isLinear(blocks []*mergedBlock) bool {
+	for i := 1; i < len(blocks); i++ {
+		if blocks[i].block.ParentId != blocks[i-1].block.Id || blocks[i].block.Number <= blocks[i-1].block.Number {
+			return false
+		}
+	}
+	return true
 }
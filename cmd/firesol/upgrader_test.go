@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/firehose-solana/migrations"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// upgraderBlock returns the block of slot with id and parent id built from the given names,
+// "103" standing for the block of slot 103 and "104b" for a fork of slot 104.
+func upgraderBlock(t *testing.T, id string, parentSlot uint64, parentID string) *pbbstream.Block {
+	t.Helper()
+
+	var slot uint64
+	_, err := fmt.Sscanf(id, "%d", &slot)
+	require.NoError(t, err)
+
+	payload, err := anypb.New(&pbsol.Block{Slot: slot, ParentSlot: parentSlot, Blockhash: id, PreviousBlockhash: parentID, Rewards: []*pbsol.Reward{
+		{Pubkey: "a", Lamports: 20},
+		{Pubkey: "b", Lamports: 10},
+	}})
+	require.NoError(t, err)
+	return &pbbstream.Block{Number: slot, Id: id, ParentId: parentID, Payload: payload}
+}
+
+// linearBlocks returns the blocks of [start, stop), the first one being the child of
+// parentSlot and each other one the child of the one before it.
+func linearBlocks(t *testing.T, parentSlot, start, stop uint64) []*pbbstream.Block {
+	var blocks []*pbbstream.Block
+	for slot := start; slot < stop; slot++ {
+		blocks = append(blocks, upgraderBlock(t, fmt.Sprint(slot), parentSlot, fmt.Sprint(parentSlot)))
+		parentSlot = slot
+	}
+	return blocks
+}
+
+func newTestUpgrader(t *testing.T, names ...string) *bundleUpgrader {
+	chain, err := migrations.NewChain(names, &migrations.Config{Logger: zap.NewNop()})
+	require.NoError(t, err)
+
+	destStore := dstore.NewMockStore(nil)
+	metadataStore := dstore.NewMockStore(nil)
+	metadataStore.SetOverwrite(true)
+	return &bundleUpgrader{
+		sourceStore:   dstore.NewMockStore(nil),
+		destStore:     destStore,
+		metadataStore: metadataStore,
+		chain:         chain,
+		logger:        zap.NewNop(),
+	}
+}
+
+func writeBundle(t *testing.T, store dstore.Store, base uint64, blocks []*pbbstream.Block) {
+	require.NoError(t, writeMergedBlocksBundle(context.Background(), store, base, blocks))
+}
+
+func readBundle(t *testing.T, store dstore.Store, base uint64) (ids []string, blocks []*pbbstream.Block, solBlocks []*pbsol.Block) {
+	err := readMergedBlocksBundle(context.Background(), store, fmt.Sprintf("%010d", base), base, base+100, func(block *pbbstream.Block, solBlock *pbsol.Block) error {
+		ids = append(ids, block.Id)
+		blocks = append(blocks, block)
+		solBlocks = append(solBlocks, solBlock)
+		return nil
+	})
+	require.NoError(t, err)
+	return
+}
+
+func Test_BundleUpgrader(t *testing.T) {
+	ctx := context.Background()
+	u := newTestUpgrader(t, "parent-num", "sort-rewards")
+
+	// Bundle 100 forks at 104, the next bundle following 104 and not 104b
+	bundle100 := linearBlocks(t, 99, 100, 104)
+	bundle100 = append(bundle100,
+		upgraderBlock(t, "104b", 103, "103"),
+		upgraderBlock(t, "104", 103, "103"),
+		upgraderBlock(t, "105b", 104, "104b"),
+		upgraderBlock(t, "199", 104, "104"),
+	)
+	writeBundle(t, u.sourceStore, 100, bundle100)
+	writeBundle(t, u.sourceStore, 200, linearBlocks(t, 199, 200, 210))
+	writeBundle(t, u.sourceStore, 300, linearBlocks(t, 209, 300, 310))
+
+	upgraded, skipped, err := u.upgradeRange(ctx, 100, 300, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), upgraded)
+	require.Equal(t, int64(0), skipped)
+
+	ids, blocks, solBlocks := readBundle(t, u.destStore, 100)
+	require.Equal(t, []string{"100", "101", "102", "103", "104", "199"}, ids, "forked blocks are dropped")
+	require.Equal(t, uint64(104), blocks[5].ParentNum)
+	require.Equal(t, int64(10), solBlocks[0].Rewards[0].Lamports)
+
+	ids, _, _ = readBundle(t, u.destStore, 200)
+	require.Len(t, ids, 10)
+
+	exists, err := u.destStore.FileExists(ctx, "0000000300")
+	require.NoError(t, err)
+	require.False(t, exists, "bundles are split on the range")
+
+	metadata, err := migrations.ReadBundleMetadata(ctx, u.metadataStore, 100)
+	require.NoError(t, err)
+	require.Equal(t, u.chain.Applied(), metadata.Migrations)
+
+	// Running again resumes, skipping the bundles upgraded with the same migrations
+	upgraded, skipped, err = u.upgradeRange(ctx, 100, 400, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), upgraded)
+	require.Equal(t, int64(2), skipped)
+
+	// Bundles upgraded with other migrations, or without metadata, are upgraded again
+	require.NoError(t, u.metadataStore.DeleteObject(ctx, "0000000200"))
+	other := newTestUpgrader(t, "parent-num")
+	other.sourceStore, other.destStore, other.metadataStore = u.sourceStore, u.destStore, u.metadataStore
+
+	upgraded, skipped, err = other.upgradeRange(ctx, 100, 400, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), upgraded)
+	require.Equal(t, int64(0), skipped)
+
+	_, _, solBlocks = readBundle(t, u.destStore, 100)
+	require.Equal(t, int64(20), solBlocks[0].Rewards[0].Lamports, "rewards are no longer sorted")
+	for _, base := range []uint64{100, 200, 300} {
+		metadata, err := migrations.ReadBundleMetadata(ctx, u.metadataStore, base)
+		require.NoError(t, err)
+		require.Equal(t, []migrations.Applied{{Name: "parent-num", Version: 1}}, metadata.Migrations)
+	}
+}
+
+func Test_BundleUpgraderFinalBlocks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fork at the end of a linear bundle", func(t *testing.T) {
+		u := newTestUpgrader(t, "parent-num")
+		writeBundle(t, u.sourceStore, 100, append(linearBlocks(t, 99, 100, 105), upgraderBlock(t, "106b", 104, "104")))
+		writeBundle(t, u.sourceStore, 200, []*pbbstream.Block{upgraderBlock(t, "200", 104, "104")})
+
+		blocks, err := u.finalBlocks(ctx, 100)
+		require.NoError(t, err)
+		require.Len(t, blocks, 5)
+		require.Equal(t, "104", blocks[4].block.Id)
+	})
+
+	t.Run("last bundle of the store", func(t *testing.T) {
+		u := newTestUpgrader(t, "parent-num")
+		writeBundle(t, u.sourceStore, 100, append(linearBlocks(t, 99, 100, 103), upgraderBlock(t, "103b", 101, "101")))
+
+		// Without next bundle, the fork reaching the highest slot is taken as final
+		blocks, err := u.finalBlocks(ctx, 100)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+		require.Equal(t, "103b", blocks[2].block.Id)
+	})
+
+	t.Run("forks leading to the highest block", func(t *testing.T) {
+		u := newTestUpgrader(t, "parent-num")
+		writeBundle(t, u.sourceStore, 100, append(linearBlocks(t, 99, 100, 103), upgraderBlock(t, "102b", 101, "101")))
+
+		_, err := u.finalBlocks(ctx, 100)
+		require.EqualError(t, err, "forks of block 102 lead to the highest block, cannot tell which one is final")
+	})
+}
+
+func Test_BundleUpgraderCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	u := newTestUpgrader(t, "parent-num")
+	for base := uint64(100); base < 400; base += 100 {
+		writeBundle(t, u.sourceStore, base, linearBlocks(t, base-91, base, base+10))
+	}
+
+	// The context is canceled once the first bundle is written
+	destStore := u.destStore.(*dstore.MockStore)
+	destStore.WriteObjectFunc = func(_ context.Context, base string, f io.Reader) error {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		destStore.SetFile(base, data)
+		cancel()
+		return nil
+	}
+
+	upgraded, _, err := u.upgradeRange(ctx, 100, 400, 1)
+	require.True(t, errors.Is(err, context.Canceled), "unexpected error %v", err)
+	require.Equal(t, int64(1), upgraded)
+	require.Len(t, destStore.Files, 1, "no bundle is upgraded once canceled")
+}
@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/streamingfast/dstore"
+)
+
+// MetadataFolder is the folder of a merged blocks store holding the metadata of its bundles.
+// Merged blocks readers look bundles up by name and walk them with numeric prefixes, leaving
+// it aside.
+const MetadataFolder = "migrations"
+
+// BundleMetadata records the migrations applied to the blocks of a merged blocks bundle.
+type BundleMetadata struct {
+	Migrations []Applied `json:"migrations"`
+}
+
+// NewMetadataStore returns the store holding the bundles metadata of the merged blocks store
+// at url.
+func NewMetadataStore(url string) (dstore.Store, error) {
+	store, err := dstore.NewStore(url, "json", "", true)
+	if err != nil {
+		return nil, err
+	}
+	return store.SubStore(MetadataFolder)
+}
+
+// WriteBundleMetadata writes the metadata of the bundle starting at base.
+func WriteBundleMetadata(ctx context.Context, store dstore.Store, base uint64, metadata *BundleMetadata) error {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("encoding bundle metadata: %w", err)
+	}
+
+	filename := fmt.Sprintf("%010d", base)
+	if err := store.WriteObject(ctx, filename, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("writing bundle metadata %s: %w", filename, err)
+	}
+	return nil
+}
+
+// ReadBundleMetadata reads the metadata of the bundle starting at base, nil when the bundle
+// has none.
+func ReadBundleMetadata(ctx context.Context, store dstore.Store, base uint64) (*BundleMetadata, error) {
+	filename := fmt.Sprintf("%010d", base)
+	exists, err := store.FileExists(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("checking bundle metadata %s: %w", filename, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	reader, err := store.OpenObject(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("opening bundle metadata %s: %w", filename, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading bundle metadata %s: %w", filename, err)
+	}
+
+	metadata := &BundleMetadata{}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return nil, fmt.Errorf("decoding bundle metadata %s: %w", filename, err)
+	}
+	return metadata, nil
+}
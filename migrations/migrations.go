@@ -0,0 +1,180 @@
+// Package migrations holds the named and versioned changes applied to existing merged blocks by
+// upgrade-merged-blocks, each one bringing blocks written by earlier versions to the current
+// format.
+package migrations
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"go.uber.org/zap"
+)
+
+// Func migrates a block in place, solBlock being its decoded payload, marshaled back into
+// block once every migration of a chain was applied.
+type Func func(ctx context.Context, block *pbbstream.Block, solBlock *pbsol.Block) error
+
+// Config holds what migrations need beside the block, a migration erroring on creation when
+// what it needs is missing.
+type Config struct {
+	// CommissionBackfiller is required by backfill-commission
+	CommissionBackfiller *fetcher.CommissionBackfiller
+	Logger               *zap.Logger
+}
+
+// Migration is a registered migration. Version is bumped whenever what the migration does
+// changes, the version applied to a bundle being recorded in its metadata.
+type Migration struct {
+	Name        string
+	Version     int
+	Description string
+	New         func(config *Config) (Func, error)
+}
+
+var registry = map[string]*Migration{}
+
+// Register adds migration to the registry, panicking when its name is already registered.
+func Register(migration *Migration) {
+	if _, found := registry[migration.Name]; found {
+		panic(fmt.Errorf("migration %q already registered", migration.Name))
+	}
+	registry[migration.Name] = migration
+}
+
+// Get returns the migration registered under name.
+func Get(name string) (*Migration, error) {
+	migration, found := registry[name]
+	if !found {
+		return nil, fmt.Errorf("unknown migration %q, known migrations are %v", name, Names())
+	}
+	return migration, nil
+}
+
+// Names returns the names of the registered migrations, sorted.
+func Names() []string {
+	out := make([]string, 0, len(registry))
+	for name := range registry {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func init() {
+	Register(&Migration{
+		Name:        "parent-num",
+		Version:     1,
+		Description: "sets the bstream block ParentNum from the Solana block parent slot",
+		New: func(_ *Config) (Func, error) {
+			return func(_ context.Context, block *pbbstream.Block, solBlock *pbsol.Block) error {
+				block.ParentNum = solBlock.ParentSlot
+				return nil
+			}, nil
+		},
+	})
+
+	Register(&Migration{
+		Name:        "sort-rewards",
+		Version:     1,
+		Description: "sorts rewards by lamports, the order of blocks converted from RPC",
+		New: func(_ *Config) (Func, error) {
+			return func(_ context.Context, _ *pbbstream.Block, solBlock *pbsol.Block) error {
+				slices.SortFunc(solBlock.Rewards, func(a, b *pbsol.Reward) int {
+					return cmp.Compare(a.Lamports, b.Lamports)
+				})
+				return nil
+			}, nil
+		},
+	})
+
+	Register(&Migration{
+		Name:        "backfill-commission",
+		Version:     1,
+		Description: "fills the commission of voting and staking rewards lacking one from an RPC endpoint",
+		New: func(config *Config) (Func, error) {
+			if config.CommissionBackfiller == nil {
+				return nil, fmt.Errorf("backfill-commission needs an RPC endpoint to get rewards from")
+			}
+			logger := config.Logger
+			return func(ctx context.Context, block *pbbstream.Block, solBlock *pbsol.Block) error {
+				filled, err := config.CommissionBackfiller.Backfill(ctx, solBlock)
+				if err != nil {
+					return err
+				}
+				if filled > 0 {
+					logger.Debug("backfilled rewards commission", zap.Uint64("block_num", block.Number), zap.Int("filled", filled))
+				}
+				return nil
+			}, nil
+		},
+	})
+}
+
+// Applied identifies a migration applied to a bundle.
+type Applied struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+// Chain applies migrations one after the other, in order.
+type Chain struct {
+	applied []Applied
+	funcs   []Func
+}
+
+// NewChain creates the migrations named by names with config.
+func NewChain(names []string, config *Config) (*Chain, error) {
+	chain := &Chain{}
+	for _, name := range names {
+		migration, err := Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(chain.applied, func(applied Applied) bool { return applied.Name == name }) {
+			return nil, fmt.Errorf("migration %q listed more than once", name)
+		}
+
+		f, err := migration.New(config)
+		if err != nil {
+			return nil, fmt.Errorf("creating migration %q: %w", name, err)
+		}
+		chain.applied = append(chain.applied, Applied{Name: migration.Name, Version: migration.Version})
+		chain.funcs = append(chain.funcs, f)
+	}
+	return chain, nil
+}
+
+// Applied returns the migrations of the chain, in order.
+func (c *Chain) Applied() []Applied {
+	return c.applied
+}
+
+// Apply runs the chain's migrations on block, decoding and encoding its payload once.
+func (c *Chain) Apply(ctx context.Context, block *pbbstream.Block) (*pbbstream.Block, error) {
+	solBlock := &pbsol.Block{}
+	if err := block.Payload.UnmarshalTo(solBlock); err != nil {
+		return nil, fmt.Errorf("unmarshaling solana block %d: %w", block.Number, err)
+	}
+	return c.ApplyDecoded(ctx, block, solBlock)
+}
+
+// ApplyDecoded runs the chain's migrations on block whose payload was already decoded as
+// solBlock, encoding solBlock back as its payload.
+func (c *Chain) ApplyDecoded(ctx context.Context, block *pbbstream.Block, solBlock *pbsol.Block) (*pbbstream.Block, error) {
+	for i, f := range c.funcs {
+		if err := f(ctx, block, solBlock); err != nil {
+			return nil, fmt.Errorf("migration %s of block %d: %w", c.applied[i].Name, block.Number, err)
+		}
+	}
+
+	if err := block.Payload.MarshalFrom(solBlock); err != nil {
+		return nil, fmt.Errorf("marshaling solana block %d: %w", block.Number, err)
+	}
+	return block, nil
+}
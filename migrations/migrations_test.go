@@ -0,0 +1,119 @@
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/firehose-solana/block/fetcher"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func testBlock(t *testing.T, solBlock *pbsol.Block) *pbbstream.Block {
+	t.Helper()
+
+	payload, err := anypb.New(solBlock)
+	require.NoError(t, err)
+	return &pbbstream.Block{Number: solBlock.Slot, Id: solBlock.Blockhash, Payload: payload}
+}
+
+func apply(t *testing.T, names []string, config *Config, solBlock *pbsol.Block) (*pbbstream.Block, *pbsol.Block) {
+	t.Helper()
+
+	chain, err := NewChain(names, config)
+	require.NoError(t, err)
+
+	block, err := chain.Apply(context.Background(), testBlock(t, solBlock))
+	require.NoError(t, err)
+
+	out := &pbsol.Block{}
+	require.NoError(t, block.Payload.UnmarshalTo(out))
+	return block, out
+}
+
+func Test_Registry(t *testing.T) {
+	require.Equal(t, []string{"backfill-commission", "parent-num", "sort-rewards"}, Names())
+
+	_, err := Get("unknown")
+	require.EqualError(t, err, `unknown migration "unknown", known migrations are [backfill-commission parent-num sort-rewards]`)
+
+	require.Panics(t, func() { Register(&Migration{Name: "parent-num"}) })
+}
+
+func Test_NewChain(t *testing.T) {
+	config := &Config{Logger: zap.NewNop()}
+
+	chain, err := NewChain([]string{"sort-rewards", "parent-num"}, config)
+	require.NoError(t, err)
+	require.Equal(t, []Applied{{Name: "sort-rewards", Version: 1}, {Name: "parent-num", Version: 1}}, chain.Applied())
+
+	_, err = NewChain([]string{"parent-num", "parent-num"}, config)
+	require.EqualError(t, err, `migration "parent-num" listed more than once`)
+
+	_, err = NewChain([]string{"backfill-commission"}, config)
+	require.EqualError(t, err, `creating migration "backfill-commission": backfill-commission needs an RPC endpoint to get rewards from`)
+}
+
+func Test_ParentNum(t *testing.T) {
+	block, _ := apply(t, []string{"parent-num"}, &Config{}, &pbsol.Block{Slot: 10, ParentSlot: 8})
+	require.Equal(t, uint64(8), block.ParentNum)
+}
+
+func Test_SortRewards(t *testing.T) {
+	_, solBlock := apply(t, []string{"sort-rewards"}, &Config{}, &pbsol.Block{Slot: 10, Rewards: []*pbsol.Reward{
+		{Pubkey: "a", Lamports: 30},
+		{Pubkey: "b", Lamports: 10},
+		{Pubkey: "c", Lamports: 20},
+	}})
+
+	var pubkeys []string
+	for _, reward := range solBlock.Rewards {
+		pubkeys = append(pubkeys, reward.Pubkey)
+	}
+	require.Equal(t, []string{"b", "c", "a"}, pubkeys)
+}
+
+func Test_BackfillCommission(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID any `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		id, _ := json.Marshal(req.ID)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"blockhash":"11111111111111111111111111111111","previousBlockhash":"11111111111111111111111111111111","parentSlot":9,"rewards":[`+
+			`{"pubkey":"SysvarRent111111111111111111111111111111111","lamports":20,"postBalance":120,"rewardType":"Voting","commission":10}]}}`, id)
+	}))
+	defer server.Close()
+
+	config := &Config{CommissionBackfiller: fetcher.NewCommissionBackfiller(rpc.New(server.URL), zap.NewNop()), Logger: zap.NewNop()}
+	_, solBlock := apply(t, []string{"backfill-commission"}, config, &pbsol.Block{Slot: 10, Rewards: []*pbsol.Reward{
+		{Pubkey: "SysvarRent111111111111111111111111111111111", Lamports: 20, PostBalance: 120, RewardType: pbsol.RewardType_Voting},
+	}})
+	require.Equal(t, "10", solBlock.Rewards[0].Commission)
+}
+
+func Test_BundleMetadata(t *testing.T) {
+	ctx := context.Background()
+	store := dstore.NewMockStore(nil)
+
+	metadata, err := ReadBundleMetadata(ctx, store, 100)
+	require.NoError(t, err)
+	require.Nil(t, metadata)
+
+	expected := &BundleMetadata{Migrations: []Applied{{Name: "parent-num", Version: 1}, {Name: "sort-rewards", Version: 1}}}
+	require.NoError(t, WriteBundleMetadata(ctx, store, 100, expected))
+
+	metadata, err = ReadBundleMetadata(ctx, store, 100)
+	require.NoError(t, err)
+	require.Equal(t, expected, metadata)
+}
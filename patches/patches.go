@@ -0,0 +1,149 @@
+// Package patches holds the corrections applied to Solana blocks by every fetcher so that the
+// blocks they produce are the same, whatever the source.
+package patches
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"sort"
+
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// FormatVersion is the patches file format version this package reads.
+const FormatVersion = 1
+
+const DefaultNetwork = "mainnet-beta"
+
+//go:embed *.yaml
+var embedded embed.FS
+
+type SlotRange struct {
+	First  uint64 `yaml:"first"`
+	Last   uint64 `yaml:"last"`
+	Reason string `yaml:"reason,omitempty"`
+}
+
+func (r SlotRange) Contains(slot uint64) bool {
+	return slot >= r.First && slot <= r.Last
+}
+
+type PreviousBlockhashPatch struct {
+	Blockhash         string `yaml:"blockhash"`
+	PreviousBlockhash string `yaml:"previous_blockhash"`
+	// Slot is informative, 0 when unknown
+	Slot uint64 `yaml:"slot,omitempty"`
+}
+
+type Registry struct {
+	Version             int                      `yaml:"version"`
+	Network             string                   `yaml:"network"`
+	Revision            int                      `yaml:"revision"`
+	SkippedSlots        []SlotRange              `yaml:"skipped_slots"`
+	PreviousBlockhashes []PreviousBlockhashPatch `yaml:"previous_blockhashes"`
+
+	previousByBlockhash map[string]string
+}
+
+// Networks returns the networks having embedded patches.
+func Networks() []string {
+	entries, _ := embedded.ReadDir(".")
+
+	var out []string
+	for _, entry := range entries {
+		out = append(out, entry.Name()[:len(entry.Name())-len(".yaml")])
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Default returns the embedded patches of network.
+func Default(network string) (*Registry, error) {
+	data, err := embedded.ReadFile(network + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("no embedded patches for network %q, known networks are %v", network, Networks())
+	}
+	return Parse(data)
+}
+
+// Load reads patches from a file, they replace the embedded ones entirely.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading patches file: %w", err)
+	}
+
+	registry, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("patches file %q: %w", path, err)
+	}
+	return registry, nil
+}
+
+// LoadOrDefault loads the patches file at path, or the embedded patches of network when path
+// is empty.
+func LoadOrDefault(path string, network string) (*Registry, error) {
+	if path != "" {
+		return Load(path)
+	}
+	return Default(network)
+}
+
+func Parse(data []byte) (*Registry, error) {
+	registry := &Registry{}
+	if err := yaml.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("decoding patches: %w", err)
+	}
+
+	if registry.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported patches format version %d, expected %d", registry.Version, FormatVersion)
+	}
+
+	for _, r := range registry.SkippedSlots {
+		if r.Last < r.First {
+			return nil, fmt.Errorf("invalid skipped slots range [%d, %d]", r.First, r.Last)
+		}
+	}
+
+	registry.previousByBlockhash = make(map[string]string, len(registry.PreviousBlockhashes))
+	for _, patch := range registry.PreviousBlockhashes {
+		if patch.Blockhash == "" || patch.PreviousBlockhash == "" {
+			return nil, fmt.Errorf("previous blockhash patch must have both blockhash and previous_blockhash set")
+		}
+		if _, found := registry.previousByBlockhash[patch.Blockhash]; found {
+			return nil, fmt.Errorf("duplicate previous blockhash patch for block %s", patch.Blockhash)
+		}
+		registry.previousByBlockhash[patch.Blockhash] = patch.PreviousBlockhash
+	}
+
+	return registry, nil
+}
+
+// IsSkipped tells if slot must be treated as skipped whatever the source returns for it.
+func (r *Registry) IsSkipped(slot uint64) bool {
+	for _, skipped := range r.SkippedSlots {
+		if skipped.Contains(slot) {
+			return true
+		}
+	}
+	return false
+}
+
+// PreviousBlockhash returns the patched previous blockhash of the block with blockhash, if any.
+func (r *Registry) PreviousBlockhash(blockhash string) (string, bool) {
+	previous, found := r.previousByBlockhash[blockhash]
+	return previous, found
+}
+
+// Apply patches blk in place, returning true when it was changed.
+func (r *Registry) Apply(blk *pbsol.Block) bool {
+	previous, found := r.PreviousBlockhash(blk.Blockhash)
+	if !found || blk.PreviousBlockhash == previous {
+		return false
+	}
+
+	blk.PreviousBlockhash = previous
+	return true
+}
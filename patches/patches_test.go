@@ -0,0 +1,132 @@
+package patches
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/streamingfast/bstream"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/dstore"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func Test_DefaultPatches(t *testing.T) {
+	require.Equal(t, []string{"devnet", "mainnet-beta", "testnet"}, Networks())
+
+	for _, network := range Networks() {
+		registry, err := Default(network)
+		require.NoError(t, err)
+		require.Equal(t, network, registry.Network)
+	}
+
+	mainnet, err := Default("mainnet-beta")
+	require.NoError(t, err)
+	require.True(t, mainnet.IsSkipped(13334464))
+	require.True(t, mainnet.IsSkipped(13334475))
+	require.False(t, mainnet.IsSkipped(13334476))
+
+	previous, found := mainnet.PreviousBlockhash("Goi3t9JjgDkyULZbM2TzE5QqHP1fPeMcHNaXNFBCBv1v")
+	require.True(t, found)
+	require.Equal(t, "HQEr9qcbUVBt2okfu755FdJvJrPYTSpzzmmyeWTj5oau", previous)
+
+	_, err = Default("unknown")
+	require.Error(t, err)
+}
+
+func Test_Parse(t *testing.T) {
+	cases := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{name: "valid", data: "version: 1\nskipped_slots:\n  - first: 10\n    last: 10\n"},
+		{name: "unsupported version", data: "version: 2\n", expectedErr: "unsupported patches format version 2, expected 1"},
+		{name: "inverted range", data: "version: 1\nskipped_slots:\n  - first: 10\n    last: 9\n", expectedErr: "invalid skipped slots range [10, 9]"},
+		{name: "missing previous", data: "version: 1\nprevious_blockhashes:\n  - blockhash: a\n", expectedErr: "previous blockhash patch must have both blockhash and previous_blockhash set"},
+		{name: "duplicate", data: "version: 1\nprevious_blockhashes:\n  - {blockhash: a, previous_blockhash: b}\n  - {blockhash: a, previous_blockhash: c}\n", expectedErr: "duplicate previous blockhash patch for block a"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Parse([]byte(c.data))
+			if c.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, c.expectedErr)
+		})
+	}
+}
+
+func Test_Apply(t *testing.T) {
+	registry, err := Parse([]byte("version: 1\nprevious_blockhashes:\n  - {blockhash: a, previous_blockhash: b}\n"))
+	require.NoError(t, err)
+
+	blk := &pbsol.Block{Blockhash: "a", PreviousBlockhash: "11111111111111111111111111111111"}
+	require.True(t, registry.Apply(blk))
+	require.Equal(t, "b", blk.PreviousBlockhash)
+	require.False(t, registry.Apply(blk))
+
+	other := &pbsol.Block{Blockhash: "c", PreviousBlockhash: "d"}
+	require.False(t, registry.Apply(other))
+	require.Equal(t, "d", other.PreviousBlockhash)
+}
+
+func Test_Verify(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	store.SetFile("0000000100", bundle(t,
+		&pbbstream.Block{Number: 110, Id: "p", ParentNum: 109},
+		&pbbstream.Block{Number: 112, Id: "a", ParentId: "p", ParentNum: 110},
+		&pbbstream.Block{Number: 120, Id: "c", ParentId: "wrong", ParentNum: 112},
+		&pbbstream.Block{Number: 130, Id: "d", ParentId: "p", ParentNum: 120},
+		&pbbstream.Block{Number: 150, Id: "x", ParentId: "d", ParentNum: 130},
+	))
+
+	registry, err := Parse([]byte(`version: 1
+skipped_slots:
+  - {first: 140, last: 149}
+  - {first: 145, last: 155}
+  - {first: 250, last: 260}
+previous_blockhashes:
+  - {blockhash: a, previous_blockhash: p, slot: 112}
+  - {blockhash: c, previous_blockhash: a, slot: 120}
+  - {blockhash: d, previous_blockhash: p, slot: 130}
+  - {blockhash: e, previous_blockhash: p}
+`))
+	require.NoError(t, err)
+
+	results, err := registry.Verify(context.Background(), store)
+	require.NoError(t, err)
+
+	var statuses []VerifyStatus
+	for _, result := range results {
+		statuses = append(statuses, result.Status)
+	}
+	require.Equal(t, []VerifyStatus{
+		VerifyOK,
+		VerifyFailed,
+		VerifyUnverified,
+		VerifyOK,
+		VerifyFailed,
+		VerifyFailed,
+		VerifyUnverified,
+	}, statuses)
+	require.Equal(t, "merged block has previous blockhash wrong, patch not applied", results[4].Detail)
+	require.Equal(t, "parent block at slot 120 is c", results[5].Detail)
+}
+
+func bundle(t *testing.T, blocks ...*pbbstream.Block) []byte {
+	t.Helper()
+
+	buf := bytes.NewBuffer(nil)
+	writer, err := bstream.NewDBinBlockWriter(buf)
+	require.NoError(t, err)
+	for _, blk := range blocks {
+		blk.Payload = &anypb.Any{TypeUrl: "type.googleapis.com/sf.solana.type.v1.Block"}
+		require.NoError(t, writer.Write(blk))
+	}
+	return buf.Bytes()
+}
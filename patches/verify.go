@@ -0,0 +1,177 @@
+package patches
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/streamingfast/bstream"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/dstore"
+)
+
+type VerifyStatus string
+
+const (
+	VerifyOK VerifyStatus = "ok"
+	// VerifyFailed means merged blocks contradict the patch
+	VerifyFailed VerifyStatus = "failed"
+	// VerifyUnverified means the patch could not be checked, its slot being unknown or its
+	// blocks missing from the store
+	VerifyUnverified VerifyStatus = "unverified"
+)
+
+type VerifyResult struct {
+	Patch  string
+	Status VerifyStatus
+	Detail string
+}
+
+// Verify checks each patch of the registry against the merged blocks of store: skipped slots
+// must have no block and patched blocks must link to a block having the patched previous
+// blockhash.
+func (r *Registry) Verify(ctx context.Context, store dstore.Store) ([]*VerifyResult, error) {
+	bundles := &bundleCache{store: store, bundles: map[uint64]map[uint64]*pbbstream.BlockMeta{}}
+
+	var out []*VerifyResult
+	for _, skipped := range r.SkippedSlots {
+		result, err := verifySkipped(ctx, bundles, skipped)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, result)
+	}
+
+	for _, patch := range r.PreviousBlockhashes {
+		result, err := verifyPreviousBlockhash(ctx, bundles, patch)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, result)
+	}
+	return out, nil
+}
+
+func verifySkipped(ctx context.Context, bundles *bundleCache, skipped SlotRange) (*VerifyResult, error) {
+	result := &VerifyResult{Patch: fmt.Sprintf("skipped slots [%d, %d]", skipped.First, skipped.Last), Status: VerifyOK}
+
+	for base := skipped.First - skipped.First%100; base <= skipped.Last; base += 100 {
+		blocks, err := bundles.get(ctx, base)
+		if err != nil {
+			return nil, err
+		}
+		if blocks == nil {
+			result.Status, result.Detail = VerifyUnverified, fmt.Sprintf("merged blocks bundle %010d not found", base)
+			return result, nil
+		}
+
+		for slot := max(base, skipped.First); slot <= min(base+99, skipped.Last); slot++ {
+			if blk, found := blocks[slot]; found {
+				result.Status, result.Detail = VerifyFailed, fmt.Sprintf("block %s found at slot %d", blk.Id, slot)
+				return result, nil
+			}
+		}
+	}
+	return result, nil
+}
+
+func verifyPreviousBlockhash(ctx context.Context, bundles *bundleCache, patch PreviousBlockhashPatch) (*VerifyResult, error) {
+	result := &VerifyResult{Patch: fmt.Sprintf("previous blockhash of %s", patch.Blockhash), Status: VerifyUnverified}
+	if patch.Slot == 0 {
+		result.Detail = "slot unknown"
+		return result, nil
+	}
+
+	blk, err := bundles.block(ctx, patch.Slot)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil {
+		result.Detail = fmt.Sprintf("no block at slot %d", patch.Slot)
+		return result, nil
+	}
+
+	result.Status = VerifyFailed
+	switch {
+	case blk.Id != patch.Blockhash:
+		result.Detail = fmt.Sprintf("block at slot %d is %s", patch.Slot, blk.Id)
+		return result, nil
+	case blk.ParentId != patch.PreviousBlockhash:
+		result.Detail = fmt.Sprintf("merged block has previous blockhash %s, patch not applied", blk.ParentId)
+		return result, nil
+	}
+
+	parent, err := bundles.block(ctx, blk.ParentNum)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case parent == nil:
+		result.Status, result.Detail = VerifyUnverified, fmt.Sprintf("no parent block at slot %d", blk.ParentNum)
+	case parent.Id != patch.PreviousBlockhash:
+		result.Detail = fmt.Sprintf("parent block at slot %d is %s", blk.ParentNum, parent.Id)
+	default:
+		result.Status = VerifyOK
+	}
+	return result, nil
+}
+
+// bundleCache reads merged blocks bundles once, by base block number, keeping only the blocks
+// metadata.
+type bundleCache struct {
+	store   dstore.Store
+	bundles map[uint64]map[uint64]*pbbstream.BlockMeta
+}
+
+func (c *bundleCache) block(ctx context.Context, slot uint64) (*pbbstream.BlockMeta, error) {
+	blocks, err := c.get(ctx, slot-slot%100)
+	if err != nil {
+		return nil, err
+	}
+	return blocks[slot], nil
+}
+
+// get returns the blocks of the bundle starting at base by number, nil if the bundle does not
+// exist.
+func (c *bundleCache) get(ctx context.Context, base uint64) (map[uint64]*pbbstream.BlockMeta, error) {
+	if blocks, found := c.bundles[base]; found {
+		return blocks, nil
+	}
+
+	filename := fmt.Sprintf("%010d", base)
+	exists, err := c.store.FileExists(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("checking merged blocks bundle %s: %w", filename, err)
+	}
+	if !exists {
+		c.bundles[base] = nil
+		return nil, nil
+	}
+
+	reader, err := c.store.OpenObject(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("opening merged blocks bundle %s: %w", filename, err)
+	}
+	defer reader.Close()
+
+	blockReader, err := bstream.NewDBinBlockReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading merged blocks bundle %s: %w", filename, err)
+	}
+
+	blocks := map[uint64]*pbbstream.BlockMeta{}
+	for {
+		blk, err := blockReader.ReadAsBlockMeta()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading block from merged blocks bundle %s: %w", filename, err)
+		}
+		blocks[blk.Number] = blk
+	}
+
+	c.bundles[base] = blocks
+	return blocks, nil
+}
@@ -27,6 +27,7 @@ function main() {
   cd "$ROOT/pb" &> /dev/null
 
   generate "sf/solana/type/v1/type.proto"
+  generate "geyser/geyser.proto"
 
   echo "generate.sh - `date` - `whoami`" > ./last_generate.txt
   echo "streamingfast/proto revision: `GIT_DIR=$PROTO/.git git rev-parse HEAD`" >> ./last_generate.txt
@@ -0,0 +1,340 @@
+package pbsol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// TransactionErrorCode is the variant of a Solana `TransactionError`, the first field of its
+// bincode encoding in TransactionError.Err.
+type TransactionErrorCode uint32
+
+const (
+	TransactionErrorCodeInstructionError                      TransactionErrorCode = 8
+	TransactionErrorCodeDuplicateInstruction                  TransactionErrorCode = 30
+	TransactionErrorCodeInsufficientFundsForRent              TransactionErrorCode = 31
+	TransactionErrorCodeProgramExecutionTemporarilyRestricted TransactionErrorCode = 35
+	// TransactionErrorCodeUnknown is the reserved code of variants the fetcher did not know,
+	// kept with their JSON representation as returned by the RPC.
+	TransactionErrorCodeUnknown TransactionErrorCode = math.MaxUint32
+)
+
+var transactionErrorNames = []string{
+	"AccountInUse",
+	"AccountLoadedTwice",
+	"AccountNotFound",
+	"ProgramAccountNotFound",
+	"InsufficientFundsForFee",
+	"InvalidAccountForFee",
+	"AlreadyProcessed",
+	"BlockhashNotFound",
+	"InstructionError",
+	"CallChainTooDeep",
+	"MissingSignatureForFee",
+	"InvalidAccountIndex",
+	"SignatureFailure",
+	"InvalidProgramForExecution",
+	"SanitizeFailure",
+	"ClusterMaintenance",
+	"AccountBorrowOutstanding",
+	"WouldExceedMaxBlockCostLimit",
+	"UnsupportedVersion",
+	"InvalidWritableAccount",
+	"WouldExceedMaxAccountCostLimit",
+	"WouldExceedAccountDataBlockLimit",
+	"TooManyAccountLocks",
+	"AddressLookupTableNotFound",
+	"InvalidAddressLookupTableOwner",
+	"InvalidAddressLookupTableData",
+	"InvalidAddressLookupTableIndex",
+	"InvalidRentPayingAccount",
+	"WouldExceedMaxVoteCostLimit",
+	"WouldExceedAccountDataTotalLimit",
+	"DuplicateInstruction",
+	"InsufficientFundsForRent",
+	"MaxLoadedAccountsDataSizeExceeded",
+	"InvalidLoadedAccountsDataSizeLimit",
+	"ResanitizationNeeded",
+	"ProgramExecutionTemporarilyRestricted",
+	"UnbalancedTransaction",
+}
+
+// String returns the name of the variant, as used by the Solana RPC.
+func (c TransactionErrorCode) String() string {
+	if int(c) < len(transactionErrorNames) {
+		return transactionErrorNames[c]
+	}
+	if c == TransactionErrorCodeUnknown {
+		return "Unknown"
+	}
+	return fmt.Sprintf("TransactionErrorCode(%d)", uint32(c))
+}
+
+// InstructionErrorCode is the variant of a Solana `InstructionError`.
+type InstructionErrorCode uint32
+
+const (
+	InstructionErrorCodeCustom       InstructionErrorCode = 25
+	InstructionErrorCodeBorshIoError InstructionErrorCode = 44
+	// InstructionErrorCodeUnknown is the reserved code of variants the fetcher did not know,
+	// kept with their JSON representation as returned by the RPC.
+	InstructionErrorCodeUnknown InstructionErrorCode = math.MaxUint32
+)
+
+var instructionErrorNames = []string{
+	"GenericError",
+	"InvalidArgument",
+	"InvalidInstructionData",
+	"InvalidAccountData",
+	"AccountDataTooSmall",
+	"InsufficientFunds",
+	"IncorrectProgramId",
+	"MissingRequiredSignature",
+	"AccountAlreadyInitialized",
+	"UninitializedAccount",
+	"UnbalancedInstruction",
+	"ModifiedProgramId",
+	"ExternalAccountLamportSpend",
+	"ExternalAccountDataModified",
+	"ReadonlyLamportChange",
+	"ReadonlyDataModified",
+	"DuplicateAccountIndex",
+	"ExecutableModified",
+	"RentEpochModified",
+	"NotEnoughAccountKeys",
+	"AccountDataSizeChanged",
+	"AccountNotExecutable",
+	"AccountBorrowFailed",
+	"AccountBorrowOutstanding",
+	"DuplicateAccountOutOfSync",
+	"Custom",
+	"InvalidError",
+	"ExecutableDataModified",
+	"ExecutableLamportChange",
+	"ExecutableAccountNotRentExempt",
+	"UnsupportedProgramId",
+	"CallDepth",
+	"MissingAccount",
+	"ReentrancyNotAllowed",
+	"MaxSeedLengthExceeded",
+	"InvalidSeeds",
+	"InvalidRealloc",
+	"ComputationalBudgetExceeded",
+	"PrivilegeEscalation",
+	"ProgramEnvironmentSetupFailure",
+	"ProgramFailedToComplete",
+	"ProgramFailedToCompile",
+	"Immutable",
+	"IncorrectAuthority",
+	"BorshIoError",
+	"AccountNotRentExempt",
+	"InvalidAccountOwner",
+	"ArithmeticOverflow",
+	"UnsupportedSysvar",
+	"IllegalOwner",
+	"MaxAccountsDataAllocationsExceeded",
+	"MaxAccountsExceeded",
+	"MaxInstructionTraceLengthExceeded",
+	"BuiltinProgramsMustConsumeComputeUnits",
+}
+
+// String returns the name of the variant, as used by the Solana RPC.
+func (c InstructionErrorCode) String() string {
+	if int(c) < len(instructionErrorNames) {
+		return instructionErrorNames[c]
+	}
+	if c == InstructionErrorCodeUnknown {
+		return "Unknown"
+	}
+	return fmt.Sprintf("InstructionErrorCode(%d)", uint32(c))
+}
+
+// DecodedTransactionError is the structured form of a TransactionError, the fields set
+// depending on Code.
+type DecodedTransactionError struct {
+	Code TransactionErrorCode
+	// Instruction is set for TransactionErrorCodeInstructionError
+	Instruction *DecodedInstructionError
+	// DuplicateInstructionIndex is set for TransactionErrorCodeDuplicateInstruction
+	DuplicateInstructionIndex uint8
+	// AccountIndex is set for TransactionErrorCodeInsufficientFundsForRent and
+	// TransactionErrorCodeProgramExecutionTemporarilyRestricted
+	AccountIndex uint8
+	// UnknownJSON is set for TransactionErrorCodeUnknown
+	UnknownJSON string
+}
+
+// DecodedInstructionError is the structured form of an `InstructionError`, the fields set
+// depending on Code.
+type DecodedInstructionError struct {
+	Index uint8
+	Code  InstructionErrorCode
+	// CustomCode is set for InstructionErrorCodeCustom
+	CustomCode uint32
+	// BorshIoErrorMessage is set for InstructionErrorCodeBorshIoError
+	BorshIoErrorMessage string
+	// UnknownJSON is set for InstructionErrorCodeUnknown
+	UnknownJSON string
+}
+
+// Decode reads the bincode encoded Solana `TransactionError` of x.
+func (x *TransactionError) Decode() (*DecodedTransactionError, error) {
+	d := &errorDecoder{data: x.Err}
+
+	code, err := d.u32()
+	if err != nil {
+		return nil, fmt.Errorf("decoding error code: %w", err)
+	}
+
+	out := &DecodedTransactionError{Code: TransactionErrorCode(code)}
+	switch out.Code {
+	case TransactionErrorCodeInstructionError:
+		out.Instruction, err = d.instructionError()
+	case TransactionErrorCodeDuplicateInstruction:
+		out.DuplicateInstructionIndex, err = d.u8()
+	case TransactionErrorCodeInsufficientFundsForRent, TransactionErrorCodeProgramExecutionTemporarilyRestricted:
+		out.AccountIndex, err = d.u8()
+	case TransactionErrorCodeUnknown:
+		out.UnknownJSON, err = d.string()
+	default:
+		if int(out.Code) >= len(transactionErrorNames) {
+			return nil, fmt.Errorf("unknown error code %d", code)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", out.Code, err)
+	}
+
+	if len(d.data) > 0 {
+		return nil, fmt.Errorf("%d trailing bytes after %s", len(d.data), out.Code)
+	}
+	return out, nil
+}
+
+// MarshalJSON renders e like the Solana RPC does, `"AccountInUse"` or
+// `{"InstructionError":[2,{"Custom":6001}]}` for example.
+func (e *DecodedTransactionError) MarshalJSON() ([]byte, error) {
+	var detail any
+	switch e.Code {
+	case TransactionErrorCodeUnknown:
+		return []byte(e.UnknownJSON), nil
+	case TransactionErrorCodeInstructionError:
+		instruction, err := e.Instruction.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		detail = json.RawMessage(instruction)
+	case TransactionErrorCodeDuplicateInstruction:
+		detail = e.DuplicateInstructionIndex
+	case TransactionErrorCodeInsufficientFundsForRent, TransactionErrorCodeProgramExecutionTemporarilyRestricted:
+		detail = map[string]uint8{"account_index": e.AccountIndex}
+	default:
+		return marshalRPCJSON(e.Code.String())
+	}
+	return marshalRPCJSON(map[string]any{e.Code.String(): detail})
+}
+
+// String returns the JSON rendering of e.
+func (e *DecodedTransactionError) String() string {
+	data, err := e.MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("%s (%s)", e.Code, err)
+	}
+	return string(data)
+}
+
+// MarshalJSON renders e like the Solana RPC does, as the `[index, error]` detail of an
+// InstructionError.
+func (e *DecodedInstructionError) MarshalJSON() ([]byte, error) {
+	var instructionErr any
+	switch e.Code {
+	case InstructionErrorCodeUnknown:
+		instructionErr = json.RawMessage(e.UnknownJSON)
+	case InstructionErrorCodeCustom:
+		instructionErr = map[string]uint32{e.Code.String(): e.CustomCode}
+	case InstructionErrorCodeBorshIoError:
+		instructionErr = map[string]string{e.Code.String(): e.BorshIoErrorMessage}
+	default:
+		instructionErr = e.Code.String()
+	}
+	return marshalRPCJSON([]any{e.Index, instructionErr})
+}
+
+// marshalRPCJSON encodes v without escaping HTML characters, like the RPC does.
+func marshalRPCJSON(v any) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+type errorDecoder struct {
+	data []byte
+}
+
+func (d *errorDecoder) instructionError() (*DecodedInstructionError, error) {
+	index, err := d.u8()
+	if err != nil {
+		return nil, fmt.Errorf("decoding instruction index: %w", err)
+	}
+	code, err := d.u32()
+	if err != nil {
+		return nil, fmt.Errorf("decoding instruction error code: %w", err)
+	}
+
+	out := &DecodedInstructionError{Index: index, Code: InstructionErrorCode(code)}
+	switch out.Code {
+	case InstructionErrorCodeCustom:
+		out.CustomCode, err = d.u32()
+	case InstructionErrorCodeBorshIoError:
+		out.BorshIoErrorMessage, err = d.string()
+	case InstructionErrorCodeUnknown:
+		out.UnknownJSON, err = d.string()
+	default:
+		if int(out.Code) >= len(instructionErrorNames) {
+			return nil, fmt.Errorf("unknown instruction error code %d", code)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", out.Code, err)
+	}
+	return out, nil
+}
+
+func (d *errorDecoder) u8() (uint8, error) {
+	if len(d.data) < 1 {
+		return 0, fmt.Errorf("expected 1 byte, got %d", len(d.data))
+	}
+	value := d.data[0]
+	d.data = d.data[1:]
+	return value, nil
+}
+
+func (d *errorDecoder) u32() (uint32, error) {
+	if len(d.data) < 4 {
+		return 0, fmt.Errorf("expected 4 bytes, got %d", len(d.data))
+	}
+	value := binary.LittleEndian.Uint32(d.data)
+	d.data = d.data[4:]
+	return value, nil
+}
+
+// string reads a u64 length prefixed string.
+func (d *errorDecoder) string() (string, error) {
+	if len(d.data) < 8 {
+		return "", fmt.Errorf("expected 8 bytes string length, got %d", len(d.data))
+	}
+	length := binary.LittleEndian.Uint64(d.data)
+	if length > uint64(len(d.data)-8) {
+		return "", fmt.Errorf("string length %d greater than the remaining %d bytes", length, len(d.data)-8)
+	}
+	value := string(d.data[8 : 8+length])
+	d.data = d.data[8+length:]
+	return value, nil
+}
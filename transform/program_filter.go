@@ -0,0 +1,164 @@
+package transform
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	pbtransforms "github.com/streamingfast/firehose-solana/pb/sf/solana/transforms/v1"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var ProgramFilterMessageName = proto.MessageName(&pbtransforms.ProgramFilter{})
+
+// BlockTransformerFactories is the set of Solana transforms, meant to be used as
+// firecore.Chain#BlockTransformerFactories.
+var BlockTransformerFactories = map[protoreflect.FullName]firecore.BlockTransformerFactory{
+	ProgramFilterMessageName: ProgramFilterFactory,
+}
+
+// RegisterTransforms registers the Solana transforms to registry, indexStore and
+// indexPossibleSizes being handed to their factories.
+func RegisterTransforms(registry *transform.Registry, indexStore dstore.Store, indexPossibleSizes []uint64) error {
+	for name, factory := range BlockTransformerFactories {
+		transformer, err := factory(indexStore, indexPossibleSizes)
+		if err != nil {
+			return fmt.Errorf("creating transform %s: %w", name, err)
+		}
+		registry.Register(transformer)
+	}
+	return nil
+}
+
+func ProgramFilterFactory(indexStore dstore.Store, indexPossibleSizes []uint64) (*transform.Factory, error) {
+	return &transform.Factory{
+		Obj: &pbtransforms.ProgramFilter{},
+		NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+			mname := message.MessageName()
+			if mname != ProgramFilterMessageName {
+				return nil, fmt.Errorf("expected type url %q, received %q", ProgramFilterMessageName, message.TypeUrl)
+			}
+
+			filter := &pbtransforms.ProgramFilter{}
+			if err := proto.Unmarshal(message.Value, filter); err != nil {
+				return nil, fmt.Errorf("unexpected unmarshal error: %w", err)
+			}
+
+			return NewProgramFilter(filter.ProgramIds)
+		},
+	}, nil
+}
+
+// ProgramFilter keeps only the transactions invoking one of its programs, either from a
+// top-level or an inner instruction.
+type ProgramFilter struct {
+	programIds [][]byte
+}
+
+func NewProgramFilter(base58ProgramIds []string) (*ProgramFilter, error) {
+	if len(base58ProgramIds) == 0 {
+		return nil, fmt.Errorf("a program filter requires at least one program id")
+	}
+
+	f := &ProgramFilter{}
+	for _, programId := range base58ProgramIds {
+		decoded, err := base58.Decode(programId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid program id %q: %w", programId, err)
+		}
+		if len(decoded) != 32 {
+			return nil, fmt.Errorf("invalid program id %q: expected 32 bytes, got %d", programId, len(decoded))
+		}
+		f.programIds = append(f.programIds, decoded)
+	}
+	return f, nil
+}
+
+func (f *ProgramFilter) String() string {
+	programIds := make([]string, len(f.programIds))
+	for i, programId := range f.programIds {
+		programIds[i] = base58.Encode(programId)
+	}
+	return fmt.Sprintf("program_filter: %v", programIds)
+}
+
+func (f *ProgramFilter) Transform(readOnlyBlk *pbbstream.Block, in transform.Input) (transform.Output, error) {
+	block, ok := in.Obj().(*pbsol.Block)
+	if !ok {
+		block = &pbsol.Block{}
+		if err := readOnlyBlk.Payload.UnmarshalTo(block); err != nil {
+			return nil, fmt.Errorf("unmarshaling solana block %d: %w", readOnlyBlk.Number, err)
+		}
+	} else {
+		block = proto.Clone(block).(*pbsol.Block)
+	}
+
+	var transactions []*pbsol.ConfirmedTransaction
+	for _, trx := range block.Transactions {
+		if f.matches(trx) {
+			transactions = append(transactions, trx)
+		}
+	}
+	block.Transactions = transactions
+
+	return block, nil
+}
+
+func (f *ProgramFilter) matches(trx *pbsol.ConfirmedTransaction) bool {
+	message := trx.GetTransaction().GetMessage()
+	if message == nil {
+		return false
+	}
+
+	accountKeys := resolvedAccountKeys(message, trx.Meta)
+	for _, instruction := range message.Instructions {
+		if f.isFilteredProgram(accountKeys, instruction.ProgramIdIndex) {
+			return true
+		}
+	}
+
+	for _, innerInstructions := range trx.GetMeta().GetInnerInstructions() {
+		for _, instruction := range innerInstructions.Instructions {
+			if f.isFilteredProgram(accountKeys, instruction.ProgramIdIndex) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (f *ProgramFilter) isFilteredProgram(accountKeys [][]byte, programIdIndex uint32) bool {
+	if int(programIdIndex) >= len(accountKeys) {
+		return false
+	}
+
+	programId := accountKeys[programIdIndex]
+	for _, filtered := range f.programIds {
+		if bytes.Equal(programId, filtered) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvedAccountKeys returns the accounts instructions index into: the static account keys
+// followed by the writable then readonly addresses loaded from address lookup tables.
+func resolvedAccountKeys(message *pbsol.Message, meta *pbsol.TransactionStatusMeta) [][]byte {
+	if len(meta.GetLoadedWritableAddresses()) == 0 && len(meta.GetLoadedReadonlyAddresses()) == 0 {
+		return message.AccountKeys
+	}
+
+	out := make([][]byte, 0, len(message.AccountKeys)+len(meta.LoadedWritableAddresses)+len(meta.LoadedReadonlyAddresses))
+	out = append(out, message.AccountKeys...)
+	out = append(out, meta.LoadedWritableAddresses...)
+	out = append(out, meta.LoadedReadonlyAddresses...)
+	return out
+}
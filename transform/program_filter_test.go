@@ -0,0 +1,150 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/mr-tron/base58"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/bstream/transform"
+	pbtransforms "github.com/streamingfast/firehose-solana/pb/sf/solana/transforms/v1"
+	pbsol "github.com/streamingfast/firehose-solana/pb/sf/solana/type/v1"
+	"github.com/test-go/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	voteProgram  = "Vote111111111111111111111111111111111111111"
+	tokenProgram = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	otherProgram = "11111111111111111111111111111111"
+)
+
+func key(t *testing.T, base58Key string) []byte {
+	t.Helper()
+	decoded, err := base58.Decode(base58Key)
+	require.NoError(t, err)
+	return decoded
+}
+
+func Test_ProgramFilter(t *testing.T) {
+	topLevel := &pbsol.ConfirmedTransaction{
+		Transaction: &pbsol.Transaction{Message: &pbsol.Message{
+			AccountKeys:  [][]byte{key(t, otherProgram), key(t, voteProgram)},
+			Instructions: []*pbsol.CompiledInstruction{{ProgramIdIndex: 1}},
+		}},
+		Meta: &pbsol.TransactionStatusMeta{},
+	}
+	inner := &pbsol.ConfirmedTransaction{
+		Transaction: &pbsol.Transaction{Message: &pbsol.Message{
+			AccountKeys:  [][]byte{key(t, otherProgram), key(t, tokenProgram)},
+			Instructions: []*pbsol.CompiledInstruction{{ProgramIdIndex: 0}},
+		}},
+		Meta: &pbsol.TransactionStatusMeta{
+			InnerInstructions: []*pbsol.InnerInstructions{{Instructions: []*pbsol.InnerInstruction{{ProgramIdIndex: 1}}}},
+		},
+	}
+	lookupTable := &pbsol.ConfirmedTransaction{
+		Transaction: &pbsol.Transaction{Message: &pbsol.Message{
+			AccountKeys:  [][]byte{key(t, otherProgram)},
+			Instructions: []*pbsol.CompiledInstruction{{ProgramIdIndex: 2}},
+		}},
+		Meta: &pbsol.TransactionStatusMeta{
+			LoadedWritableAddresses: [][]byte{key(t, otherProgram)},
+			LoadedReadonlyAddresses: [][]byte{key(t, tokenProgram)},
+		},
+	}
+	unrelated := &pbsol.ConfirmedTransaction{
+		Transaction: &pbsol.Transaction{Message: &pbsol.Message{
+			AccountKeys:  [][]byte{key(t, otherProgram), key(t, tokenProgram)},
+			Instructions: []*pbsol.CompiledInstruction{{ProgramIdIndex: 0}},
+		}},
+		Meta: &pbsol.TransactionStatusMeta{},
+	}
+
+	block := &pbsol.Block{Slot: 42, Transactions: []*pbsol.ConfirmedTransaction{topLevel, inner, lookupTable, unrelated}}
+	payload, err := anypb.New(block)
+	require.NoError(t, err)
+	bstreamBlock := &pbbstream.Block{Number: 42, Payload: payload}
+
+	cases := []struct {
+		name       string
+		programIds []string
+		expected   []*pbsol.ConfirmedTransaction
+	}{
+		{"top-level instruction", []string{voteProgram}, []*pbsol.ConfirmedTransaction{topLevel}},
+		{"inner instruction and lookup table", []string{tokenProgram}, []*pbsol.ConfirmedTransaction{inner, lookupTable}},
+		{"multiple programs", []string{voteProgram, tokenProgram}, []*pbsol.ConfirmedTransaction{topLevel, inner, lookupTable}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			filter, err := NewProgramFilter(c.programIds)
+			require.NoError(t, err)
+
+			out, err := filter.Transform(bstreamBlock, transform.NewNilObj())
+			require.NoError(t, err)
+
+			filtered := out.(*pbsol.Block)
+			require.Equal(t, uint64(42), filtered.Slot)
+			require.Len(t, filtered.Transactions, len(c.expected))
+			for i, trx := range c.expected {
+				require.True(t, proto.Equal(trx, filtered.Transactions[i]), "transaction %d mismatch", i)
+			}
+		})
+	}
+}
+
+func Test_ProgramFilterFactory(t *testing.T) {
+	factory, err := ProgramFilterFactory(nil, nil)
+	require.NoError(t, err)
+
+	registry := transform.NewRegistry()
+	registry.Register(factory)
+
+	message, err := anypb.New(&pbtransforms.ProgramFilter{ProgramIds: []string{voteProgram}})
+	require.NoError(t, err)
+	_, err = registry.New(message)
+	require.NoError(t, err)
+
+	message, err = anypb.New(&pbtransforms.ProgramFilter{ProgramIds: []string{"not-base58-0OIl"}})
+	require.NoError(t, err)
+	_, err = registry.New(message)
+	require.Error(t, err)
+
+	message, err = anypb.New(&pbtransforms.ProgramFilter{})
+	require.NoError(t, err)
+	_, err = registry.New(message)
+	require.Error(t, err)
+}
+
+// Test_RegisterTransforms resolves the program filter a Firehose client requests through a
+// registry the Solana transforms were registered to.
+func Test_RegisterTransforms(t *testing.T) {
+	registry := transform.NewRegistry()
+	require.NoError(t, RegisterTransforms(registry, nil, nil))
+
+	vote := &pbsol.ConfirmedTransaction{Transaction: &pbsol.Transaction{Message: &pbsol.Message{
+		AccountKeys:  [][]byte{key(t, otherProgram), key(t, voteProgram)},
+		Instructions: []*pbsol.CompiledInstruction{{ProgramIdIndex: 1}},
+	}}}
+	transfer := &pbsol.ConfirmedTransaction{Transaction: &pbsol.Transaction{Message: &pbsol.Message{
+		AccountKeys:  [][]byte{key(t, otherProgram)},
+		Instructions: []*pbsol.CompiledInstruction{{ProgramIdIndex: 0}},
+	}}}
+	payload, err := anypb.New(&pbsol.Block{Slot: 42, Transactions: []*pbsol.ConfirmedTransaction{vote, transfer}})
+	require.NoError(t, err)
+
+	filter, err := anypb.New(&pbtransforms.ProgramFilter{ProgramIds: []string{voteProgram}})
+	require.NoError(t, err)
+
+	preprocess, _, description, err := registry.BuildFromTransforms([]*anypb.Any{filter})
+	require.NoError(t, err)
+	require.Equal(t, "program_filter: ["+voteProgram+"]", description)
+
+	out, err := preprocess(&pbbstream.Block{Number: 42, Payload: payload})
+	require.NoError(t, err)
+
+	block := out.(*pbsol.Block)
+	require.Len(t, block.Transactions, 1)
+	require.True(t, proto.Equal(vote, block.Transactions[0]))
+}